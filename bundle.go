@@ -0,0 +1,52 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidBundle is returned for bundles that do not match requested locale & namespace or have invalid texts
+var ErrInvalidBundle = errors.New("invalid translation bundle")
+
+// Bundle is a versioned set of texts of a locale within a namespace served over HTTP, see RemoteTranslator
+type Bundle struct {
+	Locale       string            `json:"locale"`
+	Namespace    string            `json:"namespace,omitempty"`
+	Version      string            `json:"version"`
+	Translations map[string]string `json:"translations"` // texts by keys without the namespace
+}
+
+// BundlePath returns path of a bundle relative to a base URL that is the same as path of a translation file,
+// e.g. "billing/de-DE.json", see ParseTranslationFilePath
+func BundlePath(locale, namespace string) string {
+	if namespace == "" {
+		return locale + ".json"
+	}
+	return namespace + "/" + locale + ".json"
+}
+
+// validate checks that the bundle has expected locale & namespace and its texts can be parsed
+// by translators created with the options
+func (b Bundle) validate(locale, namespace string, options ...MapTranslatorOption) error {
+	if b.Locale != locale || b.Namespace != namespace {
+		return fmt.Errorf("%w: expected locale=%v&namespace=%v, got locale=%v&namespace=%v",
+			ErrInvalidBundle, locale, namespace, b.Locale, b.Namespace)
+	}
+	translations := make(map[string]map[string]string, len(b.Translations))
+	b.addTo(translations)
+	if err := ValidateTranslations(translations, options...); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	return nil
+}
+
+// addTo adds texts of the bundle to translations in the format used by NewMapTranslator
+func (b Bundle) addTo(translations map[string]map[string]string) {
+	for name, text := range b.Translations {
+		key := NamespacedKey(b.Namespace, name)
+		if translations[key] == nil {
+			translations[key] = make(map[string]string)
+		}
+		translations[key][b.Locale] = text
+	}
+}
@@ -0,0 +1,178 @@
+package i18n
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BundleSource returns translations served by NewBundleHandler, e.g. Translations method of ReloadingTranslator,
+// SQLTranslator or RemoteTranslator. Returned translations must not be modified.
+type BundleSource func() map[string]map[string]string
+
+// StaticBundleSource serves translations that never change, e.g. loaded by LoadDir
+func StaticBundleSource(translations map[string]map[string]string) BundleSource {
+	return func() map[string]map[string]string {
+		return translations
+	}
+}
+
+// TranslatorBundleSource serves texts of the keys for supported locales resolved by any Translator,
+// so texts missing in a locale are served with the same fallbacks as in Go code
+func TranslatorBundleSource(translator Translator, provider LocalesProvider, keys ...string) BundleSource {
+	return func() map[string]map[string]string {
+		translations := make(map[string]map[string]string, len(keys))
+		for _, key := range keys {
+			texts := make(map[string]string)
+			for _, locale := range provider.SupportedLocales() {
+				if result := translator.Lookup(key, locale.Code5); result.Found {
+					texts[locale.Code5] = result.Text
+				}
+			}
+			translations[key] = texts
+		}
+		return translations
+	}
+}
+
+// NewBundle creates bundle of texts of a locale within a namespace, its version is a hash of the texts
+func NewBundle(translations map[string]map[string]string, locale, namespace string) Bundle {
+	bundle := Bundle{Locale: locale, Namespace: namespace, Translations: make(map[string]string)}
+	for key, texts := range translations {
+		if ns, name := SplitNamespacedKey(key); ns == namespace {
+			if text, ok := texts[locale]; ok {
+				bundle.Translations[name] = text
+			}
+		}
+	}
+	names := make([]string, 0, len(bundle.Translations))
+	for name := range bundle.Translations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	hash := sha256.New()
+	for _, name := range names {
+		_, _ = io.WriteString(hash, name+"\x00"+bundle.Translations[name]+"\x00")
+	}
+	bundle.Version = hex.EncodeToString(hash.Sum(nil)[:8])
+	return bundle
+}
+
+// NewBundleHandler creates HTTP handler serving bundles of supported locales at BundlePath(locale, namespace),
+// e.g. "billing/de-DE.json", for RemoteTranslator and JS frontends. A namespace path ending with "/", e.g. "billing/",
+// serves the bundle of a locale negotiated by Accept-Language. Responses have ETags & are gzipped if accepted.
+// Mount it with http.StripPrefix, e.g. mux.Handle("/i18n/", http.StripPrefix("/i18n/", handler)).
+func NewBundleHandler(source BundleSource, provider LocalesProvider, defaultLocale Locale) http.Handler {
+	return bundleHandler{source: source, provider: provider, defaultLocale: defaultLocale}
+}
+
+type bundleHandler struct {
+	source        BundleSource
+	provider      LocalesProvider
+	defaultLocale Locale
+}
+
+func (h bundleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	header := w.Header()
+	var file TranslationFile
+	if name := strings.TrimPrefix(r.URL.Path, "/"); name == "" || strings.HasSuffix(name, "/") {
+		file.Locale = ResolveRequestLocale(r, h.provider, h.defaultLocale, LocaleSourceAcceptLanguage()).Code5
+		file.Namespace = strings.TrimSuffix(name, "/")
+		header.Add("Vary", "Accept-Language")
+		header.Set("Content-Location", path.Base(BundlePath(file.Locale, file.Namespace)))
+	} else if parsed, ok := ParseTranslationFilePath(name); ok && h.supported(parsed.Locale) {
+		file = parsed
+	} else {
+		http.NotFound(w, r)
+		return
+	}
+	translations := h.source()
+	if !hasNamespace(translations, file.Namespace) {
+		http.NotFound(w, r)
+		return
+	}
+	bundle := NewBundle(translations, file.Locale, file.Namespace)
+	gzipped := acceptsGzip(r)
+	header.Add("Vary", "Accept-Encoding")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("ETag", bundleETag(bundle.Version, gzipped))
+	if matchesETag(r.Header.Get("If-None-Match"), bundle.Version) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	header.Set("Content-Type", "application/json; charset=utf-8")
+	header.Set("Content-Language", file.Locale)
+	var body io.Writer = w
+	if gzipped {
+		header.Set("Content-Encoding", "gzip")
+		gzipWriter := gzip.NewWriter(w)
+		defer func() {
+			_ = gzipWriter.Close()
+		}()
+		body = gzipWriter
+	}
+	encoder := json.NewEncoder(body)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(bundle)
+}
+
+// supported tells whether the base locale of a locale code is supported, e.g. "de-DE" for "de-DE@formal"
+func (h bundleHandler) supported(locale string) bool {
+	_, err := h.provider.GetLocaleByCode5(baseLocale(locale))
+	return err == nil
+}
+
+func hasNamespace(translations map[string]map[string]string, namespace string) bool {
+	for key := range translations {
+		if ns, _ := SplitNamespacedKey(key); ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// bundleETag returns ETag of a bundle version, gzipped representation has its own ETag
+func bundleETag(version string, gzipped bool) string {
+	if gzipped {
+		return `"` + version + `-gzip"`
+	}
+	return `"` + version + `"`
+}
+
+// matchesETag tells whether If-None-Match header value matches ETag of either representation of the version
+func matchesETag(ifNoneMatch, version string) bool {
+	for _, etag := range strings.Split(ifNoneMatch, ",") {
+		etag = strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+		if etag == "*" || etag == bundleETag(version, false) || etag == bundleETag(version, true) {
+			return true
+		}
+	}
+	return false
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, coding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(coding), ";")
+		if strings.TrimSpace(name) != "gzip" {
+			continue
+		}
+		if qs, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			q, err := strconv.ParseFloat(qs, 64)
+			return err == nil && q > 0
+		}
+		return true
+	}
+	return false
+}
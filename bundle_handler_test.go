@@ -0,0 +1,185 @@
+package i18n
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBundleHandler(t *testing.T) {
+	// Prepare test data
+	translations := map[string]map[string]string{
+		"title":                           {"en-US": "Title", "de-DE": "Titel", "de-DE@formal": "Ihr Titel"},
+		"cta":                             {"en-US": "Sign up"},
+		NamespacedKey("billing", "total"): {"en-US": "Total", "de-DE": "Summe"},
+	}
+	provider := NewSupportedLocales([]string{LocaleCodeEnUS, LocaleCodeDeDE})
+	handler := NewBundleHandler(StaticBundleSource(translations), provider, LocaleEnUS)
+	version := NewBundle(translations, "de-DE", "").Version
+
+	testCases := []struct {
+		name            string
+		method          string
+		path            string
+		header          map[string]string
+		status          int
+		locale          string
+		namespace       string
+		texts           map[string]string
+		gzipped         bool
+		etag            string
+		contentLocation string
+	}{
+		{name: "Locale", path: "/de-DE.json", status: http.StatusOK, locale: "de-DE", texts: map[string]string{"title": "Titel"}, etag: `"` + version + `"`},
+		{name: "Namespace", path: "/billing/de-DE.json", status: http.StatusOK, locale: "de-DE", namespace: "billing", texts: map[string]string{"total": "Summe"}},
+		{name: "Formality variant", path: "/de-DE@formal.json", status: http.StatusOK, locale: "de-DE@formal", texts: map[string]string{"title": "Ihr Titel"}},
+		{name: "Gzip", path: "/de-DE.json", header: map[string]string{"Accept-Encoding": "gzip, br"}, status: http.StatusOK,
+			locale: "de-DE", texts: map[string]string{"title": "Titel"}, gzipped: true, etag: `"` + version + `-gzip"`},
+		{name: "Gzip not acceptable", path: "/de-DE.json", header: map[string]string{"Accept-Encoding": "gzip;q=0"}, status: http.StatusOK,
+			locale: "de-DE", texts: map[string]string{"title": "Titel"}},
+		{name: "Accept-Language", path: "/billing/", header: map[string]string{"Accept-Language": "fr-FR, de;q=0.8"}, status: http.StatusOK,
+			locale: "de-DE", namespace: "billing", texts: map[string]string{"total": "Summe"}, contentLocation: "de-DE.json"},
+		{name: "Accept-Language defaults", path: "/", status: http.StatusOK,
+			locale: "en-US", texts: map[string]string{"title": "Title", "cta": "Sign up"}, contentLocation: "en-US.json"},
+		{name: "Not modified", path: "/de-DE.json", header: map[string]string{"If-None-Match": `"other", "` + version + `-gzip"`}, status: http.StatusNotModified},
+		{name: "Unsupported locale", path: "/fr-FR.json", status: http.StatusNotFound},
+		{name: "Unknown namespace", path: "/shop/de-DE.json", status: http.StatusNotFound},
+		{name: "Not a bundle", path: "/de-DE.yaml", status: http.StatusNotFound},
+		{name: "Method not allowed", method: http.MethodPost, path: "/de-DE.json", status: http.StatusMethodNotAllowed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			request := httptest.NewRequest(method, tc.path, nil)
+			for name, value := range tc.header {
+				request.Header.Set(name, value)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			response := recorder.Result()
+			if response.StatusCode != tc.status {
+				t.Fatalf("Expected status %v, got %v", tc.status, response.StatusCode)
+			}
+			if tc.etag != "" && response.Header.Get("ETag") != tc.etag {
+				t.Errorf("Expected ETag %v, got %v", tc.etag, response.Header.Get("ETag"))
+			}
+			if location := response.Header.Get("Content-Location"); location != tc.contentLocation {
+				t.Errorf("Expected Content-Location %q, got %q", tc.contentLocation, location)
+			}
+			if tc.status != http.StatusOK {
+				return
+			}
+			var body io.Reader = response.Body
+			if gzipped := response.Header.Get("Content-Encoding") == "gzip"; gzipped != tc.gzipped {
+				t.Fatalf("Expected gzipped %v, got %v", tc.gzipped, gzipped)
+			} else if gzipped {
+				var err error
+				if body, err = gzip.NewReader(response.Body); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+			var bundle Bundle
+			if err := json.NewDecoder(body).Decode(&bundle); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if bundle.Locale != tc.locale || bundle.Namespace != tc.namespace || bundle.Version == "" {
+				t.Errorf("Unexpected bundle: %+v", bundle)
+			}
+			if len(bundle.Translations) != len(tc.texts) {
+				t.Errorf("Expected texts %v, got %v", tc.texts, bundle.Translations)
+			}
+			for name, text := range tc.texts {
+				if bundle.Translations[name] != text {
+					t.Errorf("Expected %q for %v, got %q", text, name, bundle.Translations[name])
+				}
+			}
+		})
+	}
+}
+
+func TestNewBundle(t *testing.T) {
+	// Prepare test data
+	translations := map[string]map[string]string{
+		"title":                           {"en-US": "Title", "de-DE": "Titel", "de-DE@formal": "Ihr Titel"},
+		"cta":                             {"en-US": "Sign up"},
+		NamespacedKey("billing", "total"): {"en-US": "Total", "de-DE": "Summe"},
+	}
+
+	bundle := NewBundle(translations, "de-DE", "")
+	if bundle.Version != NewBundle(translations, "de-DE", "").Version {
+		t.Error("Expected version to be stable")
+	}
+	translations["title"]["de-DE"] = "Überschrift"
+	if bundle.Version == NewBundle(translations, "de-DE", "").Version {
+		t.Error("Expected version to change with texts")
+	}
+}
+
+func TestTranslatorBundleSource(t *testing.T) {
+	// Prepare test data
+	translator := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"title": {"en-US": "Title", "de-DE": "Titel"},
+		"cta":   {"en-US": "Sign up"},
+	})
+	provider := NewSupportedLocales([]string{LocaleCodeEnUS, LocaleCodeDeDE})
+	source := TranslatorBundleSource(translator, provider, "title", "cta", "unknown")
+
+	bundle := NewBundle(source(), "de-DE", "")
+	expected := map[string]string{"title": "Titel", "cta": "Sign up"}
+	if len(bundle.Translations) != len(expected) || bundle.Translations["title"] != "Titel" || bundle.Translations["cta"] != "Sign up" {
+		t.Errorf("Expected %v, got %v", expected, bundle.Translations)
+	}
+}
+
+func TestBundleHandler_RemoteTranslator(t *testing.T) {
+	// Prepare test data
+	provider := NewSupportedLocales([]string{LocaleCodeEnUS, LocaleCodeDeDE})
+	translations := map[string]map[string]string{
+		"title":                           {"en-US": "Title", "de-DE": "Titel"},
+		NamespacedKey("billing", "total"): {"en-US": "Total", "de-DE": "Summe"},
+	}
+	source := func() map[string]map[string]string {
+		return translations
+	}
+	handler := http.StripPrefix("/i18n/", NewBundleHandler(source, provider, LocaleEnUS))
+	var revalidations atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			revalidations.Add(1)
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	translator := NewRemoteTranslator(ctx, server.URL+"/i18n", "en-US",
+		WithRemoteHTTPClient(server.Client()),
+		WithRemoteLocales("en-US", "de-DE"),
+		WithRemoteNamespaces("", "billing"),
+	)
+
+	if err := translator.Refresh(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s := translator.Translate(NamespacedKey("billing", "total"), "de-DE"); s != "Summe" {
+		t.Errorf("Expected text served by handler, got %q", s)
+	}
+	versions := translator.Versions()
+	if err := translator.Refresh(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if version := translator.Versions()["de-DE.json"]; version != versions["de-DE.json"] {
+		t.Errorf("Expected version %v to be kept, got %v", versions["de-DE.json"], version)
+	}
+	if n := revalidations.Load(); n != 4 {
+		t.Errorf("Expected 4 conditional requests, got %d", n)
+	}
+}
@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBundlePath(t *testing.T) {
+	testCases := []struct {
+		locale    string
+		namespace string
+		expected  string
+	}{
+		{locale: "de-DE", expected: "de-DE.json"},
+		{locale: "de-DE", namespace: "billing", expected: "billing/de-DE.json"},
+		{locale: "de-DE@formal", namespace: "bots/billing", expected: "bots/billing/de-DE@formal.json"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			name := BundlePath(tc.locale, tc.namespace)
+			if name != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, name)
+			}
+			file, ok := ParseTranslationFilePath(name)
+			if !ok || file.Locale != tc.locale || file.Namespace != tc.namespace {
+				t.Errorf("Expected path to be parsed back, got %+v", file)
+			}
+		})
+	}
+}
+
+func TestBundle_validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		bundle  Bundle
+		options []MapTranslatorOption
+		valid   bool
+	}{
+		{name: "Valid", bundle: Bundle{Locale: "de-DE", Namespace: "billing", Translations: map[string]string{"title": "Titel"}}, valid: true},
+		{name: "Other locale", bundle: Bundle{Locale: "fr-FR", Namespace: "billing"}},
+		{name: "Other namespace", bundle: Bundle{Locale: "de-DE"}},
+		{name: "Invalid template", bundle: Bundle{Locale: "de-DE", Namespace: "billing", Translations: map[string]string{"title": "{{.Name | unknown}}"}}},
+		{name: "Malformed placeholder", bundle: Bundle{Locale: "de-DE", Namespace: "billing", Translations: map[string]string{"title": "{name, select}"}},
+			options: []MapTranslatorOption{WithMessageEngine(EnginePlaceholders)}},
+		{name: "Placeholders", bundle: Bundle{Locale: "de-DE", Namespace: "billing", Translations: map[string]string{"title": "{name}"}},
+			options: []MapTranslatorOption{WithMessageEngine(EnginePlaceholders)}, valid: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.bundle.validate("de-DE", "billing", tc.options...)
+			if tc.valid && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if !tc.valid && !errors.Is(err, ErrInvalidBundle) {
+				t.Errorf("Expected ErrInvalidBundle, got %v", err)
+			}
+		})
+	}
+}
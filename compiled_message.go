@@ -0,0 +1,88 @@
+package i18n
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// compiledMessage is an immutable pre-parsed representation of a message text.
+// It is safe for concurrent use once created.
+type compiledMessage struct {
+	text      string
+	engine    MessageEngine
+	segments  []messageSegment // literal segments & placeholder arguments
+	parseErrs []error          // errors of placeholders parsing
+	tmpl      templateExecutor
+	tmplErr   error
+}
+
+// compileMessage pre-parses text for the engine, funcs are used only by template engines
+func compileMessage(engine MessageEngine, key, text string, funcs func() map[string]any) *compiledMessage {
+	m := compiledMessage{text: text, engine: engine}
+	switch {
+	case engine == EngineTextTemplate || engine == EngineHTMLTemplate:
+		m.tmpl, m.tmplErr = parseTemplate(engine, key, text, funcs())
+	case engine == EngineAuto && isTemplateText(text):
+		m.tmpl, m.tmplErr = parseTemplate(EngineHTMLTemplate, key, text, funcs())
+	default:
+		m.segments, m.parseErrs = parsePlaceholders(text)
+	}
+	return &m
+}
+
+func (m *compiledMessage) isTemplate() bool {
+	return m.tmpl != nil || m.tmplErr != nil
+}
+
+func (m *compiledMessage) execute(key, locale string, data any) (string, error) {
+	if m.tmplErr != nil {
+		return "", &TranslationError{Key: key, Locale: locale, Err: &templateError{action: "parse", err: m.tmplErr}}
+	}
+	var buffer bytes.Buffer
+	if err := m.tmpl.Execute(&buffer, data); err != nil {
+		return "", &TranslationError{Key: key, Locale: locale, Err: &templateError{action: "render", err: err}}
+	}
+	return buffer.String(), nil
+}
+
+func (m *compiledMessage) render(key, locale string, values any) (string, error) {
+	s, err := renderSegments(locale, m.segments, values)
+	if len(m.parseErrs) > 0 {
+		err = errors.Join(append(m.parseErrs, err)...)
+	}
+	if err != nil {
+		err = &TranslationError{Key: key, Locale: locale, Err: err}
+	}
+	return s, err
+}
+
+func isTemplateText(s string) bool {
+	return strings.Contains(s, "}}") && (strings.Contains(s, "{{.") || strings.Contains(s, "{{ ."))
+}
+
+type messageCacheKey struct {
+	key    string
+	locale string
+}
+
+// messageCache holds compiled messages by key & locale and is safe for concurrent use.
+// Readers never block: a message is compiled on first use and published with sync.Map.
+type messageCache struct {
+	messages sync.Map // messageCacheKey => *compiledMessage
+}
+
+// get returns compiled message for key & locale if it was compiled from the same text
+func (c *messageCache) get(key, locale, text string) (*compiledMessage, bool) {
+	if v, ok := c.messages.Load(messageCacheKey{key: key, locale: locale}); ok {
+		if m := v.(*compiledMessage); m.text == text {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+func (c *messageCache) put(key, locale string, m *compiledMessage) {
+	c.messages.Store(messageCacheKey{key: key, locale: locale}, m)
+}
@@ -0,0 +1,57 @@
+package i18n
+
+import (
+	"testing"
+)
+
+func TestMessageCache_Get(t *testing.T) {
+	cache := new(messageCache)
+
+	if _, ok := cache.get("greeting", "en-US", "Hello, {name}!"); ok {
+		t.Fatal("Expected empty cache")
+	}
+	m1 := compileMessage(EngineAuto, "greeting", "Hello, {name}!", nil)
+	if len(m1.segments) != 3 || m1.segments[1].placeholder == nil || m1.segments[1].placeholder.name != "name" {
+		t.Fatalf("Unexpected segments: %+v", m1.segments)
+	}
+	cache.put("greeting", "en-US", m1)
+
+	if m2, ok := cache.get("greeting", "en-US", "Hello, {name}!"); !ok || m2 != m1 {
+		t.Error("Expected the same compiled message to be returned from cache")
+	}
+	if _, ok := cache.get("greeting", "en-US", "Hi, {name}!"); ok {
+		t.Error("Expected cache miss when text changes")
+	}
+	if _, ok := cache.get("greeting", "fr-FR", "Hello, {name}!"); ok {
+		t.Error("Expected messages to be cached per locale")
+	}
+}
+
+func TestCompileMessage_Template(t *testing.T) {
+	funcs := func() map[string]any {
+		return map[string]any{"upper": func(s string) string { return s }}
+	}
+
+	m := compileMessage(EngineAuto, "template", "Hello, {{.Name | upper}}!", funcs)
+	if !m.isTemplate() || m.tmplErr != nil {
+		t.Errorf("Expected template to be compiled, got error: %v", m.tmplErr)
+	}
+	if m.segments != nil {
+		t.Error("Expected no placeholder segments for a template")
+	}
+
+	m = compileMessage(EngineAuto, "invalid", "Hello, {{.Name}} and {{.Invalid}", funcs)
+	if m.tmplErr == nil {
+		t.Error("Expected template parse error")
+	}
+
+	m = compileMessage(EngineTextTemplate, "text", "Hello, {{.}}!", funcs)
+	if s, err := m.execute("text", "en-US", "<b>"); err != nil || s != "Hello, <b>!" {
+		t.Errorf("Expected text template not to escape HTML, got %q, %v", s, err)
+	}
+
+	m = compileMessage(EnginePlaceholders, "named", "Hello, {{.}} {name}!", funcs)
+	if m.isTemplate() {
+		t.Error("Expected placeholders engine not to compile a template")
+	}
+}
@@ -0,0 +1,86 @@
+package i18n
+
+import "context"
+
+type localeContextKey struct{}
+
+type translatorContextKey struct{}
+
+type formalityContextKey struct{}
+
+// WithLocale returns a copy of the context carrying the locale
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// WithTranslator returns a copy of the context carrying the translator
+func WithTranslator(ctx context.Context, translator SingleLocaleTranslator) context.Context {
+	return context.WithValue(ctx, translatorContextKey{}, translator)
+}
+
+// WithFormality returns a copy of the context carrying formality of translations, see TranslationContext
+func WithFormality(ctx context.Context, formality Formality) context.Context {
+	return context.WithValue(ctx, formalityContextKey{}, formality)
+}
+
+// FormalityFromContext returns formality stored in the context by WithFormality or FormalityDefault
+func FormalityFromContext(ctx context.Context) Formality {
+	if ctx == nil {
+		return FormalityDefault
+	}
+	formality, _ := ctx.Value(formalityContextKey{}).(Formality)
+	return formality
+}
+
+// LocaleFromContext returns locale stored in the context by WithLocale or the locale of a translator stored by WithTranslator.
+// Returns LocaleUndefined and false if there is neither.
+func LocaleFromContext(ctx context.Context) (Locale, bool) {
+	if ctx == nil {
+		return LocaleUndefined, false
+	}
+	if locale, ok := ctx.Value(localeContextKey{}).(Locale); ok {
+		return locale, true
+	}
+	if translator, ok := ctx.Value(translatorContextKey{}).(SingleLocaleTranslator); ok {
+		return translator.Locale(), true
+	}
+	return LocaleUndefined, false
+}
+
+// TranslatorFromContext returns translator stored in the context by WithTranslator.
+// If there is none it returns false and a translator that returns keys as is for the locale of the context,
+// so the result is always safe to use.
+func TranslatorFromContext(ctx context.Context) (SingleLocaleTranslator, bool) {
+	if ctx != nil {
+		if translator, ok := ctx.Value(translatorContextKey{}).(SingleLocaleTranslator); ok {
+			return translator, true
+		}
+	}
+	locale, _ := LocaleFromContext(ctx)
+	return NewSingleMapTranslator(locale, keysTranslator{}), false
+}
+
+// keysTranslator returns keys as translations
+type keysTranslator struct{}
+
+var _ Translator = keysTranslator{}
+
+func (keysTranslator) Translate(key, _ string, _ ...any) string {
+	return key
+}
+
+func (keysTranslator) TranslateWithMap(key, _ string, _ map[string]string) string {
+	return key
+}
+
+func (keysTranslator) TranslateNoWarning(key, _ string, _ ...any) string {
+	return key
+}
+
+func (keysTranslator) Lookup(key, locale string) LookupResult {
+	return notFoundResult(key, locale)
+}
+
+func (keysTranslator) TranslateE(key, locale string, _ ...any) (string, error) {
+	return "", &TranslationError{Key: key, Locale: locale, Err: ErrKeyNotFound}
+}
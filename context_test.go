@@ -0,0 +1,103 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestWithLocale(t *testing.T) {
+	ctx := WithLocale(context.Background(), LocaleFrFR)
+	if locale, ok := LocaleFromContext(ctx); !ok || locale.Code5 != LocaleCodeFrFR {
+		t.Errorf("Expected locale %q, got %q (ok=%v)", LocaleCodeFrFR, locale.Code5, ok)
+	}
+}
+
+func TestLocaleFromContext_Defaults(t *testing.T) {
+	if locale, ok := LocaleFromContext(context.Background()); ok || locale.Code5 != LocaleCodeUndefined {
+		t.Errorf("Expected undefined locale, got %q (ok=%v)", locale.Code5, ok)
+	}
+
+	var nilCtx context.Context
+	if locale, ok := LocaleFromContext(nilCtx); ok || locale.Code5 != LocaleCodeUndefined {
+		t.Errorf("Expected undefined locale for nil context, got %q (ok=%v)", locale.Code5, ok)
+	}
+
+	translator := NewSingleMapTranslator(LocaleDeDE, mockTranslator{})
+	ctx := WithTranslator(context.Background(), translator)
+	if locale, ok := LocaleFromContext(ctx); !ok || locale.Code5 != LocaleCodeDeDE {
+		t.Errorf("Expected locale of translator %q, got %q (ok=%v)", LocaleCodeDeDE, locale.Code5, ok)
+	}
+}
+
+func TestTranslatorFromContext(t *testing.T) {
+	translator := NewSingleMapTranslator(LocaleDeDE, mockTranslator{translations: map[string]map[string]string{
+		"hello": {"de-DE": "Hallo"},
+	}})
+	ctx := WithTranslator(context.Background(), translator)
+
+	result, ok := TranslatorFromContext(ctx)
+	if !ok {
+		t.Fatal("Expected translator in context")
+	}
+	if s := result.Translate("hello"); s != "Hallo" {
+		t.Errorf("Expected %q, got %q", "Hallo", s)
+	}
+}
+
+func TestTranslatorFromContext_Default(t *testing.T) {
+	ctx := WithLocale(context.Background(), LocaleUkUA)
+
+	translator, ok := TranslatorFromContext(ctx)
+	if ok {
+		t.Error("Expected ok to be false when there is no translator in context")
+	}
+	if translator == nil {
+		t.Fatal("Expected a usable default translator")
+	}
+	if locale := translator.Locale(); locale.Code5 != LocaleCodeUkUA {
+		t.Errorf("Expected default translator for locale of context, got %q", locale.Code5)
+	}
+	if s := translator.Translate("hello", 1); s != "hello" {
+		t.Errorf("Expected key to be returned, got %q", s)
+	}
+	if s := translator.TranslateNoWarning("hello"); s != "hello" {
+		t.Errorf("Expected key to be returned, got %q", s)
+	}
+	if s := translator.TranslateWithMap("hello", map[string]string{"a": "b"}); s != "hello $EXTRA(a)" {
+		t.Errorf("Expected key to be returned, got %q", s)
+	}
+	if _, err := translator.TranslateE("hello"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected ErrKeyNotFound, got %v", err)
+	}
+}
+
+func TestKeysTranslator_TranslateWithMap(t *testing.T) {
+	if s := (keysTranslator{}).TranslateWithMap("hello", "de-DE", map[string]string{"a": "b"}); s != "hello" {
+		t.Errorf("Expected key to be returned, got %q", s)
+	}
+}
+
+func TestContext_ConcurrentRequests(t *testing.T) {
+	// Each request carries its own locale, so a shared TranslationContext serves them concurrently
+	provider := mockLocalesProvider{locales: []Locale{LocaleEnUS, LocaleFrFR}}
+	l10n := NewContext(context.Background(), provider).(*translationContext)
+	l10n.translatorProvider = func(locale string) Translator {
+		return contextMockTranslator{locale: locale}
+	}
+
+	var wg sync.WaitGroup
+	for _, locale := range []Locale{LocaleEnUS, LocaleFrFR, LocaleEnUS, LocaleFrFR} {
+		wg.Add(1)
+		go func(locale Locale) {
+			defer wg.Done()
+			ctx := WithLocale(context.Background(), locale)
+			translator := l10n.GetTranslator(ctx).(contextMockTranslator)
+			if translator.locale != locale.Code5 {
+				t.Errorf("Expected translator for locale %q, got %q", locale.Code5, translator.locale)
+			}
+		}(locale)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,53 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrKeyNotFound is returned when there are no translations for a key in any locale
+	ErrKeyNotFound = errors.New("translation key not found")
+
+	// ErrLocaleNotFound is returned when a key has translations but neither for requested nor for default locale
+	ErrLocaleNotFound = errors.New("translation not found for locale")
+
+	// ErrBadArgs is returned when arguments do not match verbs or placeholders of a message
+	ErrBadArgs = errors.New("bad translation arguments")
+
+	// ErrTemplate is returned when a message template fails to parse or execute
+	ErrTemplate = errors.New("translation template failure")
+)
+
+// TranslationError describes failed translation of a key to a locale
+type TranslationError struct {
+	Key    string
+	Locale string
+	Err    error
+}
+
+func (e *TranslationError) Error() string {
+	return fmt.Sprintf("failed to translate key=%v&locale=%v: %v", e.Key, e.Locale, e.Err)
+}
+
+func (e *TranslationError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound reports whether err means there is no translation for a key in the requested locale
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrKeyNotFound) || errors.Is(err, ErrLocaleNotFound)
+}
+
+type templateError struct {
+	action string // "parse" or "render"
+	err    error
+}
+
+func (e *templateError) Error() string {
+	return fmt.Sprintf("failed to %v template: %v", e.action, e.err)
+}
+
+func (e *templateError) Unwrap() []error {
+	return []error{ErrTemplate, e.err}
+}
@@ -0,0 +1,35 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestTranslationError(t *testing.T) {
+	err := &TranslationError{Key: "greeting", Locale: "en-US", Err: ErrKeyNotFound}
+	if expected := "failed to translate key=greeting&locale=en-US: translation key not found"; err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+	if !errors.Is(fmt.Errorf("wrapped: %w", err), ErrKeyNotFound) {
+		t.Error("Expected wrapped TranslationError to match ErrKeyNotFound")
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected bool
+	}{
+		{err: nil, expected: false},
+		{err: &TranslationError{Err: ErrKeyNotFound}, expected: true},
+		{err: &TranslationError{Err: ErrLocaleNotFound}, expected: true},
+		{err: &TranslationError{Err: ErrBadArgs}, expected: false},
+		{err: &TranslationError{Err: &templateError{action: "parse", err: errors.New("bad")}}, expected: false},
+	}
+	for _, tc := range testCases {
+		if result := IsNotFound(tc.err); result != tc.expected {
+			t.Errorf("Expected IsNotFound(%v) to return %v, got %v", tc.err, tc.expected, result)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package i18n
+
+import "strings"
+
+// Formality is a register variant of translations, e.g. formal "Sie" or informal "du" in German
+type Formality string
+
+const (
+	FormalityDefault  Formality = ""
+	FormalityFormal   Formality = "formal"
+	FormalityInformal Formality = "informal"
+)
+
+// VariantSeparator separates a variant from a locale code, e.g. "de-DE@formal"
+const VariantSeparator = "@"
+
+// VariantLocale returns code of a formality variant of a locale, e.g. "de-DE@formal".
+// Translations of variants are stored under such codes and fall back to the locale without a variant.
+func VariantLocale(code5 string, formality Formality) string {
+	if formality == FormalityDefault {
+		return code5
+	}
+	return code5 + VariantSeparator + string(formality)
+}
+
+// SplitVariantLocale is reverse of VariantLocale
+func SplitVariantLocale(locale string) (code5 string, formality Formality) {
+	code5, variant, _ := strings.Cut(locale, VariantSeparator)
+	return code5, Formality(variant)
+}
+
+// baseLocale returns locale code without a variant
+func baseLocale(locale string) string {
+	code5, _ := SplitVariantLocale(locale)
+	return code5
+}
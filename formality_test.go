@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+)
+
+func TestVariantLocale(t *testing.T) {
+	testCases := []struct {
+		code5     string
+		formality Formality
+		expected  string
+	}{
+		{code5: "de-DE", formality: FormalityDefault, expected: "de-DE"},
+		{code5: "de-DE", formality: FormalityFormal, expected: "de-DE@formal"},
+		{code5: "uk-UA", formality: FormalityInformal, expected: "uk-UA@informal"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			locale := VariantLocale(tc.code5, tc.formality)
+			if locale != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, locale)
+			}
+			if code5, formality := SplitVariantLocale(locale); code5 != tc.code5 || formality != tc.formality {
+				t.Errorf("Expected %q & %q, got %q & %q", tc.code5, tc.formality, code5, formality)
+			}
+		})
+	}
+}
+
+func TestFormality(t *testing.T) {
+	// Prepare test data
+	translations, err := LoadFS(fstest.MapFS{
+		"en-US.json":          {Data: []byte(`{"welcome": "Welcome, %s!", "bye": "Bye"}`)},
+		"de-DE.json":          {Data: []byte(`{"welcome": "Willkommen, %s!", "bye": "Tschüss"}`)},
+		"de-DE@formal.json":   {Data: []byte(`{"welcome": "Willkommen, Herr %s!", "bye": "Auf Wiedersehen"}`)},
+		"de-DE@informal.json": {Data: []byte(`{"welcome": "Hi %s!"}`)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	translator := NewMapTranslator(context.Background(), "en-US", translations)
+
+	testCases := []struct {
+		name      string
+		locale    Locale
+		formality Formality
+		key       string
+		expected  string
+	}{
+		{name: "Default", locale: LocaleDeDE, key: "bye", expected: "Tschüss"},
+		{name: "Formal", locale: LocaleDeDE, formality: FormalityFormal, key: "bye", expected: "Auf Wiedersehen"},
+		{name: "Informal", locale: LocaleDeDE, formality: FormalityInformal, key: "welcome", expected: "Hi Max!"},
+		{name: "Informal falls back to default", locale: LocaleDeDE, formality: FormalityInformal, key: "bye", expected: "Tschüss"},
+		{name: "No variants in locale", locale: LocaleFrFR, formality: FormalityFormal, key: "welcome", expected: "Welcome, Max!"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			single := NewSingleMapTranslator(tc.locale, translator, WithFormalityVariant(tc.formality))
+			if single.Locale() != tc.locale {
+				t.Errorf("Expected locale %v, got %v", tc.locale, single.Locale())
+			}
+			var args []any
+			if tc.key == "welcome" {
+				args = []any{"Max"}
+			}
+			if s, err := single.TranslateE(tc.key, args...); err != nil || s != tc.expected {
+				t.Errorf("Expected %q, got %q, %v", tc.expected, s, err)
+			}
+		})
+	}
+}
+
+func TestFormalityFromContext(t *testing.T) {
+	// Prepare test data
+	ctx := context.Background()
+	translator := NewMapTranslator(ctx, "en-US", map[string]map[string]string{
+		"bye": {"en-US": "Bye", "de-DE": "Tschüss", "de-DE@formal": "Auf Wiedersehen"},
+	})
+	translationCtx := NewContext(ctx, mockLocalesProvider{locales: []Locale{LocaleEnUS, LocaleDeDE}},
+		WithTranslatorProvider(func(string) Translator { return translator }),
+		WithDefaultLocale(LocaleEnUS),
+	)
+
+	if formality := FormalityFromContext(ctx); formality != FormalityDefault {
+		t.Errorf("Expected default formality, got %q", formality)
+	}
+	var nilCtx context.Context
+	if formality := FormalityFromContext(nilCtx); formality != FormalityDefault {
+		t.Errorf("Expected default formality for nil context, got %q", formality)
+	}
+	ctx = WithFormality(WithLocale(ctx, LocaleDeDE), FormalityFormal)
+	if formality := FormalityFromContext(ctx); formality != FormalityFormal {
+		t.Errorf("Expected formal, got %q", formality)
+	}
+	if s := translationCtx.SingleLocaleTranslator(ctx).Translate("bye"); s != "Auf Wiedersehen" {
+		t.Errorf("Expected formal translation, got %q", s)
+	}
+}
@@ -0,0 +1,278 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Money is a monetary amount to be formatted with a `{x, number, currency}` placeholder.
+// If Currency is empty the currency of the locale's region is used.
+type Money struct {
+	Amount   float64
+	Currency string // ISO 4217 code, e.g. "EUR"
+}
+
+type numberSymbols struct {
+	decimal       string
+	group         string
+	currencyFirst bool // whether currency symbol goes before the amount
+	percentSpace  bool // whether there is a space between number and % sign
+}
+
+const nbsp = "\u00a0" // non-breaking space
+
+var defaultNumberSymbols = numberSymbols{decimal: ".", group: ",", currencyFirst: true}
+
+// numberSymbolsByLang is keyed by 2-letter language code.
+var numberSymbolsByLang = map[string]numberSymbols{
+	"ar": {decimal: ".", group: ",", currencyFirst: false},
+	"de": {decimal: ",", group: ".", currencyFirst: false, percentSpace: true},
+	"en": defaultNumberSymbols,
+	"es": {decimal: ",", group: ".", currencyFirst: false, percentSpace: true},
+	"fa": {decimal: ".", group: ",", currencyFirst: false},
+	"fr": {decimal: ",", group: nbsp, currencyFirst: false, percentSpace: true},
+	"id": {decimal: ",", group: ".", currencyFirst: true},
+	"it": {decimal: ",", group: ".", currencyFirst: false},
+	"ja": defaultNumberSymbols,
+	"ko": defaultNumberSymbols,
+	"pl": {decimal: ",", group: nbsp, currencyFirst: false},
+	"pt": {decimal: ",", group: ".", currencyFirst: true},
+	"ru": {decimal: ",", group: nbsp, currencyFirst: false, percentSpace: true},
+	"tr": {decimal: ",", group: ".", currencyFirst: true},
+	"uk": {decimal: ",", group: nbsp, currencyFirst: false},
+	"uz": {decimal: ",", group: nbsp, currencyFirst: false},
+	"zh": defaultNumberSymbols,
+}
+
+// currencyByRegion is keyed by 2-letter region code.
+var currencyByRegion = map[string]string{
+	"BR": "BRL",
+	"CN": "CNY",
+	"DE": "EUR",
+	"EG": "EGP",
+	"ES": "EUR",
+	"FR": "EUR",
+	"ID": "IDR",
+	"IR": "IRR",
+	"IT": "EUR",
+	"JP": "JPY",
+	"KR": "KRW",
+	"PL": "PLN",
+	"PT": "EUR",
+	"RU": "RUB",
+	"TR": "TRY",
+	"UA": "UAH",
+	"UK": "GBP",
+	"US": "USD",
+	"UZ": "UZS",
+}
+
+var currencySymbols = map[string]string{
+	"BRL": "R$",
+	"CNY": "¥",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"KRW": "₩",
+	"PLN": "zł",
+	"RUB": "₽",
+	"TRY": "₺",
+	"UAH": "₴",
+	"USD": "$",
+}
+
+// currencies without minor units
+var currencyZeroDecimals = map[string]bool{
+	"IDR": true,
+	"IRR": true,
+	"JPY": true,
+	"KRW": true,
+}
+
+func localeLang(locale string) string {
+	if len(locale) < 2 {
+		return ""
+	}
+	return strings.ToLower(locale[:2])
+}
+
+func localeRegion(locale string) string {
+	if len(locale) < 5 {
+		return ""
+	}
+	return strings.ToUpper(locale[3:5])
+}
+
+func symbolsForLocale(locale string) numberSymbols {
+	if symbols, ok := numberSymbolsByLang[localeLang(locale)]; ok {
+		return symbols
+	}
+	return defaultNumberSymbols
+}
+
+// FormatNumber formats a number using decimal & grouping separators of the locale.
+// Supported styles are "" (up to 3 fraction digits), "integer", "percent" and "currency".
+func FormatNumber(locale string, v any, style string) (string, error) {
+	if money, ok := v.(Money); ok {
+		if style != "" && style != "currency" {
+			return "", fmt.Errorf("%w: money can not be formatted as %q", ErrPlaceholderFormat, style)
+		}
+		return formatCurrency(locale, money), nil
+	}
+	f, isInt, ok := toFloat(v)
+	if !ok {
+		return "", fmt.Errorf("%w: %T is not a number", ErrPlaceholderFormat, v)
+	}
+	symbols := symbolsForLocale(locale)
+	switch style {
+	case "":
+		if isInt {
+			return formatDecimal(symbols, f, 0, false), nil
+		}
+		return formatDecimal(symbols, f, 3, true), nil
+	case "integer":
+		return formatDecimal(symbols, math.Round(f), 0, false), nil
+	case "percent":
+		s := formatDecimal(symbols, math.Round(f*100), 0, false)
+		if symbols.percentSpace {
+			return s + nbsp + "%", nil
+		}
+		return s + "%", nil
+	case "currency":
+		return formatCurrency(locale, Money{Amount: f}), nil
+	default:
+		return "", fmt.Errorf("%w: unknown number style %q", ErrPlaceholderFormat, style)
+	}
+}
+
+func formatCurrency(locale string, money Money) string {
+	currency := money.Currency
+	if currency == "" {
+		currency = currencyByRegion[localeRegion(locale)]
+	}
+	decimals := 2
+	if currencyZeroDecimals[currency] {
+		decimals = 0
+	}
+	symbols := symbolsForLocale(locale)
+	amount := formatDecimal(symbols, math.Abs(money.Amount), decimals, false)
+	symbol, ok := currencySymbols[currency]
+	if !ok {
+		symbol = currency
+	}
+	var s string
+	switch {
+	case currency == "":
+		s = amount
+	case symbols.currencyFirst && ok && utf8.RuneCountInString(symbol) == 1:
+		s = symbol + amount
+	case symbols.currencyFirst:
+		s = symbol + nbsp + amount
+	default:
+		s = amount + nbsp + symbol
+	}
+	if money.Amount < 0 {
+		return "-" + s
+	}
+	return s
+}
+
+func formatDecimal(symbols numberSymbols, f float64, decimals int, trimZeros bool) string {
+	s := strconv.FormatFloat(math.Abs(f), 'f', decimals, 64)
+	intPart, fraction, _ := strings.Cut(s, ".")
+	if trimZeros {
+		fraction = strings.TrimRight(fraction, "0")
+	}
+	var b strings.Builder
+	if f < 0 && strings.Trim(s, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(symbols.group)
+		}
+		b.WriteRune(c)
+	}
+	if fraction != "" {
+		b.WriteString(symbols.decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+func toFloat(v any) (f float64, isInt bool, ok bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true, true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), false, true
+	default:
+		return 0, false, false
+	}
+}
+
+// datePatternsByLocale is keyed by either 5-character locale code or 2-letter language code.
+var datePatternsByLocale = map[string]string{
+	"en-US": "1/2/2006",
+	"en":    "02/01/2006",
+	"de":    "02.01.2006",
+	"es":    "02/01/2006",
+	"fr":    "02/01/2006",
+	"id":    "02/01/2006",
+	"it":    "02/01/2006",
+	"ja":    "2006/01/02",
+	"ko":    "2006. 1. 2.",
+	"pl":    "02.01.2006",
+	"pt":    "02/01/2006",
+	"ru":    "02.01.2006",
+	"tr":    "02.01.2006",
+	"uk":    "02.01.2006",
+	"uz":    "02.01.2006",
+	"zh":    "2006/1/2",
+}
+
+// FormatDate formats a date for the locale. Supported styles are "" & "short" (locale's numeric date) and "iso".
+func FormatDate(locale string, t time.Time, style string) (string, error) {
+	locale = baseLocale(locale)
+	switch style {
+	case "", "short":
+		if pattern, ok := datePatternsByLocale[locale]; ok {
+			return t.Format(pattern), nil
+		}
+		if pattern, ok := datePatternsByLocale[localeLang(locale)]; ok {
+			return t.Format(pattern), nil
+		}
+		return t.Format(time.DateOnly), nil
+	case "iso":
+		return t.Format(time.DateOnly), nil
+	default:
+		return "", fmt.Errorf("%w: unknown date style %q", ErrPlaceholderFormat, style)
+	}
+}
+
+// FormatTime formats a time of day for the locale. Supported styles are "" & "short" (hours and minutes) and "medium" (with seconds).
+func FormatTime(locale string, t time.Time, style string) (string, error) {
+	twelveHours := baseLocale(locale) == LocaleCodeEnUS
+	switch style {
+	case "", "short":
+		if twelveHours {
+			return t.Format("3:04 PM"), nil
+		}
+		return t.Format("15:04"), nil
+	case "medium":
+		if twelveHours {
+			return t.Format("3:04:05 PM"), nil
+		}
+		return t.Format(time.TimeOnly), nil
+	default:
+		return "", fmt.Errorf("%w: unknown time style %q", ErrPlaceholderFormat, style)
+	}
+}
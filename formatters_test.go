@@ -0,0 +1,100 @@
+package i18n
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFormatNumber(t *testing.T) {
+	testCases := []struct {
+		name     string
+		locale   string
+		value    any
+		style    string
+		expected string
+	}{
+		{name: "Integer en-US", locale: "en-US", value: 1234567, expected: "1,234,567"},
+		{name: "Negative float en-US", locale: "en-US", value: -1234.5, expected: "-1,234.5"},
+		{name: "Small number", locale: "en-US", value: 12, expected: "12"},
+		{name: "Float de-DE", locale: "de-DE", value: 1234.5, expected: "1.234,5"},
+		{name: "Float uk-UA", locale: "uk-UA", value: 1234.5, expected: "1 234,5"},
+		{name: "Integer style rounds", locale: "en-US", value: 2.6, style: "integer", expected: "3"},
+		{name: "Percent", locale: "en-US", value: 0.125, style: "percent", expected: "13%"},
+		{name: "Currency JPY without decimals", locale: "ja-JP", value: 1500, style: "currency", expected: "¥1,500"},
+		{name: "Currency code without symbol", locale: "en-US", value: Money{Amount: 10, Currency: "CHF"}, expected: "CHF 10.00"},
+		{name: "Negative currency", locale: "en-UK", value: -3.5, style: "currency", expected: "-£3.50"},
+		{name: "Unknown locale", locale: "xx", value: 1000.25, expected: "1,000.25"},
+		{name: "Invalid locale", locale: "x", value: 1000.25, expected: "1,000.25"},
+		{name: "Currency without region", locale: "x", value: 5, style: "currency", expected: "5.00"},
+		{name: "Unsigned integer", locale: "en-US", value: uint(1234), expected: "1,234"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := FormatNumber(tc.locale, tc.value, tc.style)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected FormatNumber(%q, %v, %q) to return %q, got %q",
+					tc.locale, tc.value, tc.style, tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatNumber_Errors(t *testing.T) {
+	if _, err := FormatNumber("en-US", "abc", ""); !errors.Is(err, ErrPlaceholderFormat) {
+		t.Errorf("Expected ErrPlaceholderFormat for non-number, got %v", err)
+	}
+	if _, err := FormatNumber("en-US", 1, "scientific"); !errors.Is(err, ErrPlaceholderFormat) {
+		t.Errorf("Expected ErrPlaceholderFormat for unknown style, got %v", err)
+	}
+	if _, err := FormatNumber("en-US", Money{Amount: 1}, "percent"); !errors.Is(err, ErrPlaceholderFormat) {
+		t.Errorf("Expected ErrPlaceholderFormat for money as percent, got %v", err)
+	}
+}
+
+func TestFormatDateAndTime(t *testing.T) {
+	when := time.Date(2024, time.December, 31, 9, 5, 7, 0, time.UTC)
+
+	testCases := []struct {
+		locale       string
+		expectedDate string
+		expectedTime string
+	}{
+		{locale: "en-US", expectedDate: "12/31/2024", expectedTime: "9:05 AM"},
+		{locale: "en-UK", expectedDate: "31/12/2024", expectedTime: "09:05"},
+		{locale: "de-DE", expectedDate: "31.12.2024", expectedTime: "09:05"},
+		{locale: "ja-JP", expectedDate: "2024/12/31", expectedTime: "09:05"},
+		{locale: "xx-XX", expectedDate: "2024-12-31", expectedTime: "09:05"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.locale, func(t *testing.T) {
+			if date, _ := FormatDate(tc.locale, when, ""); date != tc.expectedDate {
+				t.Errorf("Expected date %q, got %q", tc.expectedDate, date)
+			}
+			if tm, _ := FormatTime(tc.locale, when, ""); tm != tc.expectedTime {
+				t.Errorf("Expected time %q, got %q", tc.expectedTime, tm)
+			}
+		})
+	}
+
+	if date, _ := FormatDate("en-US", when, "iso"); date != "2024-12-31" {
+		t.Errorf("Expected ISO date, got %q", date)
+	}
+	if tm, _ := FormatTime("de-DE", when, "medium"); tm != "09:05:07" {
+		t.Errorf("Expected medium time, got %q", tm)
+	}
+	if tm, _ := FormatTime("en-US", when, "medium"); tm != "9:05:07 AM" {
+		t.Errorf("Expected medium 12-hour time, got %q", tm)
+	}
+	if _, err := FormatDate("en-US", when, "full"); !errors.Is(err, ErrPlaceholderFormat) {
+		t.Errorf("Expected ErrPlaceholderFormat, got %v", err)
+	}
+	if _, err := FormatTime("en-US", when, "full"); !errors.Is(err, ErrPlaceholderFormat) {
+		t.Errorf("Expected ErrPlaceholderFormat for unknown time style, got %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package i18n
+
+import "fmt"
+
+// Gender is a grammatical gender used by select placeholders, e.g. {user, select, female {...} other {...}}
+type Gender string
+
+const (
+	GenderMale   Gender = "male"
+	GenderFemale Gender = "female"
+	GenderOther  Gender = "other"
+)
+
+// GrammaticalCase is a case to inflect names to with case placeholders, e.g. {user, case, genitive}
+type GrammaticalCase string
+
+const (
+	CaseNominative   GrammaticalCase = "nominative"
+	CaseGenitive     GrammaticalCase = "genitive"
+	CaseDative       GrammaticalCase = "dative"
+	CaseAccusative   GrammaticalCase = "accusative"
+	CaseInstrumental GrammaticalCase = "instrumental"
+	CaseLocative     GrammaticalCase = "locative"
+	CaseVocative     GrammaticalCase = "vocative"
+)
+
+// Pronouns are preferred pronoun forms of a person in the language of a message, e.g. they/them/their
+type Pronouns struct {
+	Subject    string
+	Object     string
+	Possessive string
+}
+
+// Person is a message argument about a user. In messages with placeholders it's rendered as:
+//
+//	{user}                                - name
+//	{user, select, male {...} other {...}} - text by gender
+//	{user, case, genitive}                - name inflected with explicit Cases or the Inflector
+//	{user, pronoun, object}               - preferred pronoun: subject, object or possessive
+type Person struct {
+	Name     string
+	Gender   Gender
+	Pronouns Pronouns
+	Cases    map[GrammaticalCase]string // explicit inflected forms of the name
+}
+
+var _ fmt.Stringer = (*Person)(nil)
+
+func (p Person) String() string {
+	return p.Name
+}
+
+// MapArgs returns arguments of the person for TranslateWithMap: {name} for the name, {name.gender} for the gender,
+// {name.pronouns.subject} etc. for pronouns and {name.cases.genitive} etc. for Cases. Select, case & pronoun placeholders
+// of the name, e.g. {user, pronoun, subject}, read these arguments as for a Person. Use TranslateWithValues to pass a Person.
+func (p Person) MapArgs(name string) map[string]string {
+	args := map[string]string{
+		name:             p.Name,
+		name + ".gender": string(p.Gender),
+	}
+	for form, pronoun := range map[string]string{"subject": p.Pronouns.Subject, "object": p.Pronouns.Object, "possessive": p.Pronouns.Possessive} {
+		if pronoun != "" {
+			args[name+".pronouns."+form] = pronoun
+		}
+	}
+	for grammaticalCase, form := range p.Cases {
+		args[name+".cases."+string(grammaticalCase)] = form
+	}
+	return args
+}
+
+func (p Person) pronoun(form string) (string, error) {
+	var pronoun string
+	switch form {
+	case "subject":
+		pronoun = p.Pronouns.Subject
+	case "object":
+		pronoun = p.Pronouns.Object
+	case "possessive":
+		pronoun = p.Pronouns.Possessive
+	default:
+		return "", fmt.Errorf("%w: unknown pronoun form %q", ErrPlaceholderFormat, form)
+	}
+	if pronoun == "" {
+		return p.Name, fmt.Errorf("%w: no %v pronoun for %v", ErrPlaceholderFormat, form, p.Name)
+	}
+	return pronoun, nil
+}
+
+// Inflector returns word inflected to the grammatical case, gender can be empty if unknown
+type Inflector func(locale, word string, gender Gender, grammaticalCase GrammaticalCase) string
+
+// inflector is used by case placeholders, see SetInflector
+var inflector Inflector
+
+// SetInflector sets hook used by case placeholders to inflect names, e.g. for Ukrainian, Russian or Polish.
+// Should be called at initialization, without it names are not inflected unless Person.Cases has the form.
+func SetInflector(f Inflector) {
+	inflector = f
+}
+
+func inflect(locale string, v any, grammaticalCase GrammaticalCase) string {
+	var word string
+	var gender Gender
+	switch v := v.(type) {
+	case Person:
+		if s, ok := v.Cases[grammaticalCase]; ok {
+			return s
+		}
+		word, gender = v.Name, v.Gender
+	case *Person:
+		return inflect(locale, *v, grammaticalCase)
+	default:
+		word = fmt.Sprint(v)
+	}
+	if f := inflector; f != nil && grammaticalCase != CaseNominative {
+		return f(locale, word, gender, grammaticalCase)
+	}
+	return word
+}
+
+// selectKey returns option name of a select placeholder for a value
+func selectKey(v any) string {
+	switch v := v.(type) {
+	case Person:
+		return string(v.Gender)
+	case *Person:
+		return string(v.Gender)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,129 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testInflector(locale, word string, gender Gender, grammaticalCase GrammaticalCase) string {
+	if locale == "uk-UA" && grammaticalCase == CaseDative && gender == GenderMale && strings.HasSuffix(word, "о") {
+		return strings.TrimSuffix(word, "о") + "ові"
+	}
+	return word
+}
+
+func TestFormatMessage_Person(t *testing.T) {
+	SetInflector(testInflector)
+	defer SetInflector(nil)
+
+	// Prepare test data
+	petro := Person{Name: "Петро", Gender: GenderMale}
+	olena := Person{Name: "Олена", Gender: GenderFemale, Cases: map[GrammaticalCase]string{CaseDative: "Олені"}}
+	alex := Person{Name: "Alex", Gender: GenderOther, Pronouns: Pronouns{Subject: "they", Object: "them", Possessive: "their"}}
+	const joined = "{user, select, male {{user} приєднався} female {{user} приєдналася} other {{user} приєдналися}}"
+
+	testCases := []struct {
+		name     string
+		locale   string
+		message  string
+		args     any
+		expected string
+		errIs    error
+	}{
+		{name: "Select male", locale: "uk-UA", message: joined, args: map[string]any{"user": petro}, expected: "Петро приєднався"},
+		{name: "Select female", locale: "uk-UA", message: joined, args: map[string]any{"user": olena}, expected: "Олена приєдналася"},
+		{name: "Select other", locale: "uk-UA", message: joined, args: map[string]any{"user": &alex}, expected: "Alex приєдналися"},
+		{name: "Select string", locale: "en-US", message: "{kind, select, bot {Bot} other {User}}", args: map[string]any{"kind": "bot"}, expected: "Bot"},
+		{name: "Inflector", locale: "uk-UA", message: "Надіслано {user, case, dative}", args: map[string]any{"user": petro}, expected: "Надіслано Петрові"},
+		{name: "Explicit case", locale: "uk-UA", message: "Надіслано {user, case, dative}", args: map[string]any{"user": olena}, expected: "Надіслано Олені"},
+		{name: "Pronoun", locale: "en-US", message: "Say hi to {user, pronoun, object}", args: map[string]any{"user": alex}, expected: "Say hi to them"},
+		{name: "Field of argument", locale: "en-US", message: "{user} ({user.gender}), {user.pronouns.possessive} profile", args: map[string]any{"user": alex}, expected: "Alex (other), their profile"},
+		{name: "Missing pronoun", locale: "en-US", message: "{user, pronoun, object}", args: map[string]any{"user": petro}, expected: "Петро", errIs: ErrPlaceholderFormat},
+		{name: "No select option", locale: "en-US", message: "{user, select, male {He}}", args: map[string]any{"user": alex}, expected: "", errIs: ErrPlaceholderFormat},
+		{name: "Malformed select", locale: "en-US", message: "{user, select, male He}", args: map[string]any{"user": alex}, expected: "{user, select, male He}", errIs: ErrPlaceholderSyntax},
+		{name: "Select number", locale: "en-US", message: "{n, select, 1 {One} other {Many}}", args: map[string]any{"n": 1}, expected: "One"},
+		{name: "Malformed select option", locale: "en-US", message: "{user, select, he-him {He}}", args: map[string]any{"user": alex}, expected: "{user, select, he-him {He}}", errIs: ErrPlaceholderSyntax},
+		{name: "Malformed select text", locale: "en-US", message: "{user, select, male {He {}}}", args: map[string]any{"user": alex}, expected: "{user, select, male {He {}}}", errIs: ErrPlaceholderSyntax},
+		{name: "Case of pointer", locale: "uk-UA", message: "Надіслано {user, case, dative}", args: map[string]any{"user": &petro}, expected: "Надіслано Петрові"},
+		{name: "Case of string", locale: "uk-UA", message: "Надіслано {user, case, dative}", args: map[string]any{"user": "Петро"}, expected: "Надіслано Петро"},
+		{name: "Nominative case", locale: "uk-UA", message: "{user, case, nominative}", args: map[string]any{"user": petro}, expected: "Петро"},
+		{name: "Possessive pronoun of pointer", locale: "en-US", message: "{user, pronoun, possessive} profile", args: map[string]any{"user": &alex}, expected: "their profile"},
+		{name: "Unknown pronoun form", locale: "en-US", message: "{user, pronoun, reflexive}", args: map[string]any{"user": alex}, expected: "Alex", errIs: ErrPlaceholderFormat},
+		{name: "Pronoun of string", locale: "en-US", message: "{user, pronoun, subject}", args: map[string]any{"user": "Alex"}, expected: "Alex", errIs: ErrPlaceholderFormat},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := FormatMessage(tc.locale, tc.message, tc.args)
+			if result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+			if !errors.Is(err, tc.errIs) {
+				t.Errorf("Expected error %v, got %v", tc.errIs, err)
+			}
+		})
+	}
+}
+
+func TestPerson_MapArgs(t *testing.T) {
+	// Prepare test data
+	translator := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"joined": {
+			"en-US": "{user} joined {user.gender, select, male {his} female {her} other {{user.pronouns.possessive}}} team",
+		},
+		"hello": {"en-US": "Hello, {user}!"},
+	})
+	person := Person{Name: "Alex", Gender: GenderOther, Pronouns: Pronouns{Possessive: "their"}}
+
+	if s := translator.TranslateWithMap("joined", "en-US", person.MapArgs("user")); s != "Alex joined their team" {
+		t.Errorf("Unexpected result: %q", s)
+	}
+	if s := translator.TranslateWithMap("hello", "en-US", map[string]string{"user": "Alex"}); s != "Hello, Alex!" {
+		t.Errorf("Unexpected result: %q", s)
+	}
+}
+
+func TestTranslateWithMap_Person(t *testing.T) {
+	// Prepare test data
+	translator := NewMapTranslator(context.Background(), "uk-UA", map[string]map[string]string{
+		"said":    {"en-US": "{user} said {user, pronoun, subject} is here"},
+		"joined":  {"en-US": "{user} joined {user, select, male {his} female {her} other {their}} team"},
+		"message": {"uk-UA": "Повідомлення для {user, case, dative}"},
+		"profile": {"uk-UA": "Профіль {user, case, genitive}"},
+	})
+	olena := Person{Name: "Olena", Gender: GenderFemale, Pronouns: Pronouns{Subject: "she"}, Cases: map[GrammaticalCase]string{CaseGenitive: "Олени"}}
+	var inflected Gender
+	SetInflector(func(locale, word string, gender Gender, grammaticalCase GrammaticalCase) string {
+		inflected = gender
+		return word + "і"
+	})
+	defer SetInflector(nil)
+
+	testCases := []struct {
+		name     string
+		key      string
+		locale   string
+		expected string
+	}{
+		{name: "Pronoun", key: "said", locale: "en-US", expected: "Olena said she is here"},
+		{name: "Select by gender", key: "joined", locale: "en-US", expected: "Olena joined her team"},
+		{name: "Case", key: "message", locale: "uk-UA", expected: "Повідомлення для Olenaі"},
+		{name: "Explicit case", key: "profile", locale: "uk-UA", expected: "Профіль Олени"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if s := translator.TranslateWithMap(tc.key, tc.locale, olena.MapArgs("user")); s != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, s)
+			}
+			if expected, _ := TranslateWithValues(translator, tc.key, tc.locale, map[string]any{"user": olena}); expected != tc.expected {
+				t.Errorf("Expected the same result as TranslateWithValues %q", expected)
+			}
+		})
+	}
+	if inflected != GenderFemale {
+		t.Errorf("Expected inflector to get gender of the person, got %q", inflected)
+	}
+}
@@ -0,0 +1,143 @@
+package i18n
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LocaleSource provides candidate locale codes of a request in order of preference
+type LocaleSource struct {
+	codes func(r *http.Request, provider LocalesProvider) []string
+	vary  []string // request headers the source depends on
+}
+
+// LocaleSourcePathPrefix takes locale from the first segment of URL path matching Locale.SiteCode(), e.g. "/fr/about"
+func LocaleSourcePathPrefix() LocaleSource {
+	return LocaleSource{codes: func(r *http.Request, provider LocalesProvider) []string {
+		prefix, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		if prefix == "" {
+			return nil
+		}
+		for _, locale := range provider.SupportedLocales() {
+			if locale.SiteCode() == strings.ToLower(prefix) {
+				return []string{locale.Code5}
+			}
+		}
+		return nil
+	}}
+}
+
+// LocaleSourceQuery takes locale from a query parameter, e.g. "?lang=de-DE"
+func LocaleSourceQuery(param string) LocaleSource {
+	return LocaleSource{codes: func(r *http.Request, _ LocalesProvider) []string {
+		if code := r.URL.Query().Get(param); code != "" {
+			return []string{code}
+		}
+		return nil
+	}}
+}
+
+// LocaleSourceCookie takes locale from a cookie
+func LocaleSourceCookie(name string) LocaleSource {
+	return LocaleSource{vary: []string{"Cookie"}, codes: func(r *http.Request, _ LocalesProvider) []string {
+		if cookie, err := r.Cookie(name); err == nil && cookie.Value != "" {
+			return []string{cookie.Value}
+		}
+		return nil
+	}}
+}
+
+// LocaleSourceFunc takes locale from a callback, e.g. a preference of an authenticated user.
+// Return an empty string if there is no preference. Vary lists request headers the callback reads,
+// e.g. "Cookie" or "Authorization", so shared caches don't serve a response in a locale of another user.
+func LocaleSourceFunc(f func(r *http.Request) string, vary ...string) LocaleSource {
+	return LocaleSource{vary: vary, codes: func(r *http.Request, _ LocalesProvider) []string {
+		if code := f(r); code != "" {
+			return []string{code}
+		}
+		return nil
+	}}
+}
+
+// LocaleSourceAcceptLanguage takes locales from Accept-Language header ordered by quality
+func LocaleSourceAcceptLanguage() LocaleSource {
+	return LocaleSource{vary: []string{"Accept-Language"}, codes: func(r *http.Request, _ LocalesProvider) []string {
+		return ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	}}
+}
+
+// ParseAcceptLanguage returns language tags of Accept-Language header value ordered by quality
+func ParseAcceptLanguage(header string) []string {
+	type weightedTag struct {
+		tag string
+		q   float64
+	}
+	var tags []weightedTag
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag = strings.TrimSpace(tag); tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if qs, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(qs, 64); err != nil {
+				continue
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weightedTag{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.tag
+	}
+	return result
+}
+
+// ResolveRequestLocale returns the first locale of the sources supported by the provider or the default locale
+func ResolveRequestLocale(r *http.Request, provider LocalesProvider, defaultLocale Locale, sources ...LocaleSource) Locale {
+	for _, source := range sources {
+		if locale, ok := resolveLocale(provider, source.codes(r, provider)); ok {
+			return locale
+		}
+	}
+	return defaultLocale
+}
+
+// NewLocaleMiddleware creates HTTP middleware that resolves request locale from the sources (Accept-Language by default),
+// stores the locale & a SingleLocaleTranslator for it in request context and sets Content-Language & Vary response headers.
+// Use LocaleFromContext and TranslatorFromContext in handlers.
+func NewLocaleMiddleware(provider LocalesProvider, translator Translator, defaultLocale Locale, sources ...LocaleSource) func(http.Handler) http.Handler {
+	if len(sources) == 0 {
+		sources = []LocaleSource{LocaleSourceAcceptLanguage()}
+	}
+	var vary []string
+	for _, source := range sources {
+		for _, header := range source.vary {
+			if !slices.Contains(vary, header) {
+				vary = append(vary, header)
+			}
+		}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale := ResolveRequestLocale(r, provider, defaultLocale, sources...)
+			ctx := WithLocale(r.Context(), locale)
+			ctx = WithTranslator(ctx, NewSingleMapTranslator(locale, translator))
+			header := w.Header()
+			header.Set("Content-Language", locale.Code5)
+			for _, v := range vary {
+				header.Add("Vary", v)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
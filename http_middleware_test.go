@@ -0,0 +1,128 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	testCases := []struct {
+		header   string
+		expected []string
+	}{
+		{header: "", expected: []string{}},
+		{header: "de-DE", expected: []string{"de-DE"}},
+		{header: "fr;q=0.5, en-GB;q=0.8, ru", expected: []string{"ru", "en-GB", "fr"}},
+		{header: "*, uk;q=0, es;q=abc, it;q=0.1", expected: []string{"it"}},
+	}
+	for _, tc := range testCases {
+		if result := ParseAcceptLanguage(tc.header); !reflect.DeepEqual(result, tc.expected) {
+			t.Errorf("Expected ParseAcceptLanguage(%q) to return %v, got %v", tc.header, tc.expected, result)
+		}
+	}
+}
+
+func TestResolveRequestLocale(t *testing.T) {
+	provider := NewSupportedLocales([]string{LocaleCodeEnUS, LocaleCodeFrFR, LocaleCodeRuRU, LocaleCodePtBR})
+	sources := []LocaleSource{
+		LocaleSourcePathPrefix(),
+		LocaleSourceQuery("lang"),
+		LocaleSourceCookie("locale"),
+		LocaleSourceFunc(func(r *http.Request) string { return r.Header.Get("X-User-Locale") }),
+		LocaleSourceAcceptLanguage(),
+	}
+
+	testCases := []struct {
+		name     string
+		url      string
+		cookie   string
+		headers  map[string]string
+		expected string
+	}{
+		{name: "Default", url: "/", expected: LocaleCodeEnUS},
+		{name: "Path prefix", url: "/fr/about?lang=ru-RU", expected: LocaleCodeFrFR},
+		{name: "Path prefix with full site code", url: "/pt-br/", expected: LocaleCodePtBR},
+		{name: "Unsupported path prefix", url: "/about?lang=ru_ru", expected: LocaleCodeRuRU},
+		{name: "Unsupported query", url: "/?lang=de-DE", cookie: "fr-FR", expected: LocaleCodeFrFR},
+		{name: "User preference", url: "/", headers: map[string]string{"X-User-Locale": "ru-RU", "Accept-Language": "fr"}, expected: LocaleCodeRuRU},
+		{name: "Accept-Language by language", url: "/", headers: map[string]string{"Accept-Language": "de-DE, fr-CA;q=0.9"}, expected: LocaleCodeFrFR},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			if tc.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: "locale", Value: tc.cookie})
+			}
+			for k, v := range tc.headers {
+				r.Header.Set(k, v)
+			}
+			if locale := ResolveRequestLocale(r, provider, LocaleEnUS, sources...); locale.Code5 != tc.expected {
+				t.Errorf("Expected locale %q, got %q", tc.expected, locale.Code5)
+			}
+		})
+	}
+}
+
+func TestNewLocaleMiddleware(t *testing.T) {
+	// Prepare test data
+	provider := NewSupportedLocales([]string{LocaleCodeEnUS, LocaleCodeDeDE})
+	translator := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"hello": {"en-US": "Hello", "de-DE": "Hallo"},
+	})
+
+	var handlerLocale Locale
+	var handlerText string
+	userLocale := LocaleSourceFunc(func(r *http.Request) string { return "" }, "Authorization", "Cookie")
+	handler := NewLocaleMiddleware(provider, translator, LocaleEnUS, LocaleSourceCookie("lang"), userLocale, LocaleSourceAcceptLanguage())(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var ok bool
+			if handlerLocale, ok = LocaleFromContext(r.Context()); !ok {
+				t.Error("Expected locale in request context")
+			}
+			if translator, ok := TranslatorFromContext(r.Context()); ok {
+				handlerText = translator.Translate("hello")
+			} else {
+				t.Error("Expected translator in request context")
+			}
+		}),
+	)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Language", "de")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if handlerLocale.Code5 != LocaleCodeDeDE {
+		t.Errorf("Expected locale %q, got %q", LocaleCodeDeDE, handlerLocale.Code5)
+	}
+	if handlerText != "Hallo" {
+		t.Errorf("Expected translated text %q, got %q", "Hallo", handlerText)
+	}
+	if v := w.Header().Get("Content-Language"); v != LocaleCodeDeDE {
+		t.Errorf("Expected Content-Language %q, got %q", LocaleCodeDeDE, v)
+	}
+	if v := w.Header().Values("Vary"); !reflect.DeepEqual(v, []string{"Cookie", "Authorization", "Accept-Language"}) {
+		t.Errorf("Expected Vary to be [Cookie Authorization Accept-Language], got %v", v)
+	}
+}
+
+func TestNewLocaleMiddleware_DefaultSource(t *testing.T) {
+	provider := NewSupportedLocales([]string{LocaleCodeEnUS, LocaleCodeDeDE})
+	handler := NewLocaleMiddleware(provider, mockTranslator{}, LocaleEnUS)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Language", "fr-FR")
+	handler.ServeHTTP(w, r)
+
+	if v := w.Header().Get("Content-Language"); v != LocaleCodeEnUS {
+		t.Errorf("Expected default locale %q, got %q", LocaleCodeEnUS, v)
+	}
+	if v := w.Header().Get("Vary"); v != "Accept-Language" {
+		t.Errorf("Expected Vary %q, got %q", "Accept-Language", v)
+	}
+}
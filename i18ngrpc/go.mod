@@ -0,0 +1,20 @@
+module github.com/strongo/i18n/i18ngrpc
+
+go 1.23.0
+
+toolchain go1.26.4
+
+require (
+	github.com/strongo/i18n v0.0.0-00010101000000-000000000000
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250324211829-b45e905df463
+	google.golang.org/grpc v1.73.0
+	google.golang.org/protobuf v1.36.6
+)
+
+require (
+	golang.org/x/net v0.38.0 // indirect
+	golang.org/x/sys v0.31.0 // indirect
+	golang.org/x/text v0.23.0 // indirect
+)
+
+replace github.com/strongo/i18n => ../
@@ -0,0 +1,99 @@
+// Package i18ngrpc propagates locale between gRPC clients and servers
+package i18ngrpc
+
+import (
+	"context"
+
+	"github.com/strongo/i18n"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// MetadataKeyLocale carries exact locale code set by a client, takes precedence over MetadataKeyAcceptLanguage
+	MetadataKeyLocale = "x-locale"
+
+	// MetadataKeyAcceptLanguage carries Accept-Language of the original HTTP request
+	MetadataKeyAcceptLanguage = "accept-language"
+)
+
+// Resolver resolves locale of incoming calls and injects it with a translator into handler context
+type Resolver struct {
+	Provider      i18n.LocalesProvider
+	Translator    i18n.Translator
+	DefaultLocale i18n.Locale
+}
+
+// NewResolver creates Resolver
+func NewResolver(provider i18n.LocalesProvider, translator i18n.Translator, defaultLocale i18n.Locale) Resolver {
+	return Resolver{Provider: provider, Translator: translator, DefaultLocale: defaultLocale}
+}
+
+// Locale returns locale of incoming metadata of the context
+func (r Resolver) Locale(ctx context.Context) i18n.Locale {
+	md, _ := metadata.FromIncomingContext(ctx)
+	codes := md.Get(MetadataKeyLocale)
+	for _, header := range md.Get(MetadataKeyAcceptLanguage) {
+		codes = append(codes, i18n.ParseAcceptLanguage(header)...)
+	}
+	return i18n.ResolveLocale(r.Provider, r.DefaultLocale, codes...)
+}
+
+// Context returns context with resolved locale & translator, see i18n.LocaleFromContext and i18n.TranslatorFromContext
+func (r Resolver) Context(ctx context.Context) context.Context {
+	locale := r.Locale(ctx)
+	ctx = i18n.WithLocale(ctx, locale)
+	return i18n.WithTranslator(ctx, i18n.NewSingleMapTranslator(locale, r.Translator))
+}
+
+// UnaryServerInterceptor injects locale of incoming calls into handler context
+func (r Resolver) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(r.Context(ctx), req)
+	}
+}
+
+// StreamServerInterceptor injects locale of incoming streams into handler context
+func (r Resolver) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, localizedServerStream{ServerStream: ss, ctx: r.Context(ss.Context())})
+	}
+}
+
+type localizedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s localizedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// outgoingContext adds locale of the context to outgoing metadata
+func outgoingContext(ctx context.Context) context.Context {
+	locale, ok := i18n.LocaleFromContext(ctx)
+	if !ok || locale.Code5 == i18n.LocaleCodeUndefined {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(MetadataKeyLocale)) > 0 {
+		return ctx // set explicitly by caller
+	}
+	return metadata.AppendToOutgoingContext(ctx,
+		MetadataKeyLocale, locale.Code5,
+		MetadataKeyAcceptLanguage, locale.Code5,
+	)
+}
+
+// UnaryClientInterceptor sends locale of the call context (see i18n.WithLocale) in metadata
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor sends locale of the stream context (see i18n.WithLocale) in metadata
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
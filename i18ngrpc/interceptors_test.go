@@ -0,0 +1,194 @@
+package i18ngrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/strongo/i18n"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// localeService replies with locale of handler context and a translated greeting
+type localeService struct{}
+
+func (localeService) reply(ctx context.Context) *wrapperspb.StringValue {
+	locale, _ := i18n.LocaleFromContext(ctx)
+	translator, _ := i18n.TranslatorFromContext(ctx)
+	return wrapperspb.String(locale.Code5 + ":" + translator.Translate("hello"))
+}
+
+var localeServiceDesc = grpc.ServiceDesc{
+	ServiceName: "test.Locale",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				in := new(wrapperspb.StringValue)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, req any) (any, error) {
+					if req.(*wrapperspb.StringValue).GetValue() == "fail" {
+						return nil, LocalizedError(ctx, codes.InvalidArgument, "bad request", "bad_request")
+					}
+					return srv.(localeService).reply(ctx), nil
+				}
+				return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/test.Locale/Get"}, handler)
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			ServerStreams: true,
+			Handler: func(srv any, stream grpc.ServerStream) error {
+				in := new(wrapperspb.StringValue)
+				if err := stream.RecvMsg(in); err != nil {
+					return err
+				}
+				return stream.SendMsg(srv.(localeService).reply(stream.Context()))
+			},
+		},
+	},
+}
+
+func newTestConn(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	provider := i18n.NewSupportedLocales([]string{i18n.LocaleCodeEnUS, i18n.LocaleCodeDeDE, i18n.LocaleCodeUkUA})
+	translator := i18n.NewMapTranslator(context.Background(), i18n.LocaleCodeEnUS, map[string]map[string]string{
+		"hello":       {"en-US": "Hello", "de-DE": "Hallo", "uk-UA": "Привіт"},
+		"bad_request": {"en-US": "Bad request", "de-DE": "Ungültige Anfrage"},
+	})
+	resolver := NewResolver(provider, translator, i18n.LocaleEnUS)
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(resolver.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(resolver.StreamServerInterceptor()),
+	)
+	server.RegisterService(&localeServiceDesc, localeService{})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(StreamClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return conn
+}
+
+func TestUnaryInterceptors(t *testing.T) {
+	conn := newTestConn(t)
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{
+			name:     "Default locale",
+			ctx:      context.Background(),
+			expected: "en-US:Hello",
+		},
+		{
+			name:     "Locale of client context",
+			ctx:      i18n.WithLocale(context.Background(), i18n.LocaleDeDE),
+			expected: "de-DE:Hallo",
+		},
+		{
+			name:     "Accept-Language metadata",
+			ctx:      metadata.AppendToOutgoingContext(context.Background(), MetadataKeyAcceptLanguage, "fr-FR, uk;q=0.9"),
+			expected: "uk-UA:Привіт",
+		},
+		{
+			name: "Explicit x-locale takes precedence",
+			ctx: metadata.AppendToOutgoingContext(i18n.WithLocale(context.Background(), i18n.LocaleDeDE),
+				MetadataKeyLocale, "uk-UA"),
+			expected: "uk-UA:Привіт",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reply := new(wrapperspb.StringValue)
+			if err := conn.Invoke(tc.ctx, "/test.Locale/Get", wrapperspb.String(""), reply); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if reply.GetValue() != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, reply.GetValue())
+			}
+		})
+	}
+}
+
+func TestStreamInterceptors(t *testing.T) {
+	conn := newTestConn(t)
+
+	ctx := i18n.WithLocale(context.Background(), i18n.LocaleUkUA)
+	stream, err := conn.NewStream(ctx, &localeServiceDesc.Streams[0], "/test.Locale/Watch")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err = stream.SendMsg(wrapperspb.String("")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err = stream.CloseSend(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	reply := new(wrapperspb.StringValue)
+	if err = stream.RecvMsg(reply); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := "uk-UA:Привіт"; reply.GetValue() != expected {
+		t.Errorf("Expected %q, got %q", expected, reply.GetValue())
+	}
+}
+
+func TestLocalizedError(t *testing.T) {
+	conn := newTestConn(t)
+
+	ctx := i18n.WithLocale(context.Background(), i18n.LocaleDeDE)
+	err := conn.Invoke(ctx, "/test.Locale/Get", wrapperspb.String("fail"), new(wrapperspb.StringValue))
+	if code := status.Code(err); code != codes.InvalidArgument {
+		t.Fatalf("Expected code %v, got %v", codes.InvalidArgument, code)
+	}
+	if message := status.Convert(err).Message(); message != "bad request" {
+		t.Errorf("Expected developer message %q, got %q", "bad request", message)
+	}
+	localized, ok := LocalizedMessage(err)
+	if !ok {
+		t.Fatal("Expected LocalizedMessage detail")
+	}
+	if localized.GetLocale() != "de-DE" || localized.GetMessage() != "Ungültige Anfrage" {
+		t.Errorf("Unexpected localized message: %v", localized)
+	}
+}
+
+func TestLocalizedMessage_NotStatus(t *testing.T) {
+	if _, ok := LocalizedMessage(context.Canceled); ok {
+		t.Error("Expected no LocalizedMessage for non-status error")
+	}
+	if _, ok := LocalizedMessage(status.Error(codes.Internal, "internal")); ok {
+		t.Error("Expected no LocalizedMessage for status without details")
+	}
+}
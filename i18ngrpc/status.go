@@ -0,0 +1,45 @@
+package i18ngrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/strongo/i18n"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LocalizedStatus creates status with a developer facing message and a LocalizedMessage detail
+// translated by the translator of the context (see i18n.TranslatorFromContext)
+func LocalizedStatus(ctx context.Context, code codes.Code, message, key string, args ...any) *status.Status {
+	translator, _ := i18n.TranslatorFromContext(ctx)
+	st := status.New(code, message)
+	localized := &errdetails.LocalizedMessage{
+		Locale:  translator.Locale().Code5,
+		Message: translator.Translate(key, args...),
+	}
+	if withDetails, err := st.WithDetails(localized); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// LocalizedError is a shortcut for LocalizedStatus(...).Err()
+func LocalizedError(ctx context.Context, code codes.Code, message, key string, args ...any) error {
+	return LocalizedStatus(ctx, code, message, key, args...).Err()
+}
+
+// LocalizedMessage returns LocalizedMessage detail of a gRPC status error
+func LocalizedMessage(err error) (*errdetails.LocalizedMessage, bool) {
+	var st interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &st) {
+		return nil, false
+	}
+	for _, detail := range st.GRPCStatus().Details() {
+		if localized, ok := detail.(*errdetails.LocalizedMessage); ok {
+			return localized, true
+		}
+	}
+	return nil, false
+}
@@ -5,6 +5,10 @@ type Translator interface {
 	Translate(key, locale string, args ...any) string
 	TranslateWithMap(key, locale string, args map[string]string) string
 	TranslateNoWarning(key, locale string, args ...any) string
+	// TranslateE returns an error (see ErrKeyNotFound, ErrLocaleNotFound, ErrBadArgs, ErrTemplate) instead of panicking
+	TranslateE(key, locale string, args ...any) (string, error)
+	// Lookup tells whether a key has translation for the locale and where its text comes from
+	Lookup(key, locale string) LookupResult
 }
 
 // SingleLocaleTranslator should be implemente by translators to a single language
@@ -13,6 +17,8 @@ type SingleLocaleTranslator interface {
 	Translate(key string, args ...any) string
 	TranslateWithMap(key string, args map[string]string) string
 	TranslateNoWarning(key string, args ...any) string
+	TranslateE(key string, args ...any) (string, error)
+	Lookup(key string) LookupResult
 }
 
 // LocalesProvider provides locale by code
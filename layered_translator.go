@@ -0,0 +1,115 @@
+package i18n
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Layer is a named source of translations of a LayeredTranslator, e.g. "tenant", "experiment" or "defaults"
+type Layer struct {
+	Name       string
+	Translator Translator
+}
+
+// LayeredOption configures translator created by NewLayeredTranslator
+type LayeredOption func(t *layeredTranslator)
+
+// WithLayeredLogger sets logger of keys missing in all layers overriding the package one set by SetLogger
+func WithLayeredLogger(logger Logger) LayeredOption {
+	return func(t *layeredTranslator) {
+		t.logger = logger
+	}
+}
+
+// NewLayeredTranslator creates translator looking up keys in layers in order of priority.
+// A translation for the requested locale in any layer wins over a fallback to the default locale of an upper layer,
+// so overrides that exist only in the default locale do not hide translations of lower layers.
+// Missing keys are returned as is.
+func NewLayeredTranslator(c context.Context, layers []Layer, options ...LayeredOption) Translator {
+	t := layeredTranslator{c: c, layers: layers}
+	for _, option := range options {
+		option(&t)
+	}
+	return t
+}
+
+type layeredTranslator struct {
+	c      context.Context
+	layers []Layer
+	logger Logger
+}
+
+var _ Translator = (*layeredTranslator)(nil)
+
+// find returns the layer that has translation of the key for the locale or else the first one having a fallback
+func (t layeredTranslator) find(key, locale string) (layer Layer, result LookupResult) {
+	var path []string
+	for _, l := range t.layers {
+		r := l.Translator.Lookup(key, locale)
+		path = append(path, r.FallbackPath...)
+		if !r.Found {
+			continue
+		}
+		if r.Source == "" {
+			r.Source = l.Name
+		}
+		if !r.Fallback() {
+			r.FallbackPath = path
+			return l, r
+		}
+		if !result.Found {
+			layer, result = l, r
+		}
+	}
+	if !result.Found {
+		return layer, notFoundResult(key, locale, path...)
+	}
+	return layer, result
+}
+
+func (t layeredTranslator) Lookup(key, locale string) LookupResult {
+	_, result := t.find(key, locale)
+	return result
+}
+
+func (t layeredTranslator) notFound(warn bool, key, locale string) (string, error) {
+	if warn {
+		logAttrs(t.c, t.logger, slog.LevelWarn, "Translation not found in any layer",
+			slog.String(LogAttrKey, key), slog.String(LogAttrLocale, locale))
+	}
+	return key, &TranslationError{Key: key, Locale: locale, Err: ErrKeyNotFound}
+}
+
+func (t layeredTranslator) translate(warn bool, key, locale string, args ...any) (string, error) {
+	layer, result := t.find(key, locale)
+	if !result.Found {
+		return t.notFound(warn, key, locale)
+	}
+	if !warn {
+		return layer.Translator.TranslateNoWarning(key, locale, args...), nil
+	}
+	return layer.Translator.TranslateE(key, locale, args...)
+}
+
+func (t layeredTranslator) Translate(key, locale string, args ...any) string {
+	s, _ := t.translate(true, key, locale, args...)
+	return s
+}
+
+func (t layeredTranslator) TranslateNoWarning(key, locale string, args ...any) string {
+	s, _ := t.translate(false, key, locale, args...)
+	return s
+}
+
+func (t layeredTranslator) TranslateWithMap(key, locale string, args map[string]string) string {
+	layer, result := t.find(key, locale)
+	if !result.Found {
+		s, _ := t.notFound(true, key, locale)
+		return placeMapValues(locale, s, args)
+	}
+	return layer.Translator.TranslateWithMap(key, locale, args)
+}
+
+func (t layeredTranslator) TranslateE(key, locale string, args ...any) (string, error) {
+	return t.translate(true, key, locale, args...)
+}
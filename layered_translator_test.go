@@ -0,0 +1,94 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLayeredTranslator_TranslateE(t *testing.T) {
+	// Prepare test data
+	ctx := context.Background()
+	translator := NewLayeredTranslator(ctx, []Layer{
+		{Name: "tenant", Translator: NewMapTranslator(ctx, "en-US", map[string]map[string]string{
+			"title":   {"en-US": "Acme"},
+			"welcome": {"en-US": "Welcome to Acme, %v!"},
+		})},
+		{Name: "experiment", Translator: NewMapTranslator(ctx, "en-US", map[string]map[string]string{
+			"cta": {"en-US": "Try it now", "de-DE": "Jetzt testen"},
+		})},
+		{Name: "defaults", Translator: NewMapTranslator(ctx, "en-US", map[string]map[string]string{
+			"title":   {"en-US": "Title", "de-DE": "Titel"},
+			"welcome": {"en-US": "Welcome, %v!", "de-DE": "Willkommen, %v!"},
+			"cta":     {"en-US": "Sign up", "de-DE": "Registrieren"},
+		})},
+		{Name: "library", Translator: keysTranslator{}},
+	})
+
+	testCases := []struct {
+		name     string
+		key      string
+		locale   string
+		args     []any
+		expected string
+		notFound bool
+	}{
+		{name: "Override", key: "welcome", locale: "en-US", args: []any{"Max"}, expected: "Welcome to Acme, Max!"},
+		{name: "Lower layer has requested locale", key: "welcome", locale: "de-DE", args: []any{"Max"}, expected: "Willkommen, Max!"},
+		{name: "Middle layer", key: "cta", locale: "de-DE", expected: "Jetzt testen"},
+		{name: "Fallback of the top layer", key: "title", locale: "fr-FR", expected: "Acme"},
+		{name: "Missing", key: "unknown", locale: "de-DE", expected: "unknown", notFound: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := translator.TranslateE(tc.key, tc.locale, tc.args...)
+			if result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+			if IsNotFound(err) != tc.notFound {
+				t.Errorf("Expected not found %v, got %v", tc.notFound, err)
+			}
+			if s := translator.Translate(tc.key, tc.locale, tc.args...); s != tc.expected {
+				t.Errorf("Expected Translate() to return %q, got %q", tc.expected, s)
+			}
+			if s := translator.TranslateNoWarning(tc.key, tc.locale, tc.args...); s != tc.expected {
+				t.Errorf("Expected TranslateNoWarning() to return %q, got %q", tc.expected, s)
+			}
+		})
+	}
+}
+
+func TestLayeredTranslator_TranslateWithMap(t *testing.T) {
+	// Prepare test data
+	ctx := context.Background()
+	translator := NewLayeredTranslator(ctx, []Layer{
+		{Name: "tenant", Translator: NewMapTranslator(ctx, "en-US", map[string]map[string]string{
+			"title": {"en-US": "Acme"},
+		})},
+		{Name: "defaults", Translator: NewMapTranslator(ctx, "en-US", map[string]map[string]string{
+			"bye": {"en-US": "Bye {name}"},
+		})},
+		{Name: "library", Translator: keysTranslator{}},
+	})
+	if s := translator.TranslateWithMap("bye", "de-DE", map[string]string{"name": "Max"}); s != "Bye Max" {
+		t.Errorf("Unexpected result: %q", s)
+	}
+	if s := translator.TranslateWithMap("unknown", "de-DE", nil); s != "unknown" {
+		t.Errorf("Expected key for missing translation, got %q", s)
+	}
+}
+
+func TestWithLayeredLogger(t *testing.T) {
+	// Prepare test data
+	logger := &mockLogger{}
+	translator := NewLayeredTranslator(context.Background(), []Layer{{Name: "defaults", Translator: keysTranslator{}}}, WithLayeredLogger(logger))
+
+	_ = translator.TranslateNoWarning("unknown", "de-DE")
+	if logger.warningCalled {
+		t.Error("Expected no warning for TranslateNoWarning")
+	}
+	_ = translator.Translate("unknown", "de-DE")
+	if !logger.warningCalled {
+		t.Error("Expected missing key to be logged by the logger of the translator")
+	}
+}
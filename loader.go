@@ -0,0 +1,171 @@
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+// Translation files are JSON objects with texts by key, nested objects are flattened with "." separator:
+//
+//	{"greeting": "Hallo, %s!", "menu": {"settings": "Einstellungen"}}
+//
+// Path of a file defines its locale and namespace:
+//
+//	de-DE.json              - no namespace
+//	billing/de-DE.json      - namespace "billing"
+//	bots/billing.de-DE.json - namespace "bots/billing"
+//	de-DE@formal.json       - formal variant of the locale, see VariantLocale
+//
+// Files with names that are not locale codes, e.g. package.json, are skipped.
+
+var (
+	// ErrInvalidTranslationFile is returned for translation files that can not be parsed
+	ErrInvalidTranslationFile = errors.New("invalid translation file")
+
+	// ErrDuplicateTranslation is returned when the same key & locale is defined more than once
+	ErrDuplicateTranslation = errors.New("duplicate translation")
+)
+
+// TranslationFile describes locale & namespace of a translation file
+type TranslationFile struct {
+	Path      string
+	Locale    string
+	Namespace string
+}
+
+// ParseTranslationFilePath returns locale & namespace of a translation file by its path in a file system
+func ParseTranslationFilePath(name string) (file TranslationFile, ok bool) {
+	if path.Ext(name) != ".json" {
+		return file, false
+	}
+	dir, base := path.Split(strings.TrimSuffix(name, ".json"))
+	namespace := strings.TrimSuffix(dir, "/")
+	if i := strings.LastIndexByte(base, '.'); i >= 0 {
+		namespace = path.Join(namespace, base[:i])
+		base = base[i+1:]
+	}
+	if !isLocaleCode(base) {
+		return file, false
+	}
+	return TranslationFile{Path: name, Locale: base, Namespace: namespace}, true
+}
+
+// isLocaleCode tells whether s looks like a locale code with an optional variant, e.g. "de", "de-DE" or "de-DE@formal",
+// so other JSON files like package.json are not taken for translation files
+func isLocaleCode(s string) bool {
+	code, variant, hasVariant := strings.Cut(s, VariantSeparator)
+	if hasVariant && !isLocaleSubtag(variant, 1, 16, false) {
+		return false
+	}
+	subtags := strings.Split(strings.ReplaceAll(code, "_", "-"), "-")
+	if !isLocaleSubtag(subtags[0], 2, 3, false) {
+		return false
+	}
+	for _, subtag := range subtags[1:] {
+		if !isLocaleSubtag(subtag, 2, 8, true) {
+			return false
+		}
+	}
+	return true
+}
+
+// isLocaleSubtag tells whether s has from minLen to maxLen lowercase letters, or also uppercase letters & digits if alnum
+func isLocaleSubtag(s string, minLen, maxLen int, alnum bool) bool {
+	if len(s) < minLen || len(s) > maxLen {
+		return false
+	}
+	for _, r := range s {
+		if !('a' <= r && r <= 'z' || alnum && ('A' <= r && r <= 'Z' || '0' <= r && r <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadDir loads translation files from a directory, see LoadFS
+func LoadDir(dir string) (map[string]map[string]string, error) {
+	return LoadFS(os.DirFS(dir))
+}
+
+// LoadFS loads all translation files of a file system into translations suitable for NewMapTranslator.
+// Keys are prefixed with namespaces defined by paths of files, see NamespacedKey.
+func LoadFS(fsys fs.FS) (map[string]map[string]string, error) {
+	translations := make(map[string]map[string]string)
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		file, ok := ParseTranslationFilePath(name)
+		if !ok {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		if err = LoadJSON(translations, data, file.Locale, file.Namespace); err != nil {
+			return fmt.Errorf("%v: %w", name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// LoadJSON adds translations of a locale from JSON content to translations, keys are prefixed with the namespace
+func LoadJSON(translations map[string]map[string]string, data []byte, locale, namespace string) error {
+	var content map[string]any
+	if err := json.Unmarshal(data, &content); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTranslationFile, err)
+	}
+	return addJSONTranslations(translations, content, "", locale, namespace)
+}
+
+func addJSONTranslations(translations map[string]map[string]string, content map[string]any, prefix, locale, namespace string) error {
+	for k, v := range content {
+		key := prefix + k
+		switch v := v.(type) {
+		case string:
+			key = NamespacedKey(namespace, key)
+			texts := translations[key]
+			if texts == nil {
+				texts = make(map[string]string)
+				translations[key] = texts
+			} else if _, exists := texts[locale]; exists {
+				return fmt.Errorf("%w: key=%v&locale=%v", ErrDuplicateTranslation, key, locale)
+			}
+			texts[locale] = v
+		case map[string]any:
+			if err := addJSONTranslations(translations, v, key+".", locale, namespace); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: value of %q is %T, expected a string or an object", ErrInvalidTranslationFile, key, v)
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes translations of a locale within a namespace in the format read by LoadJSON
+func WriteJSON(w io.Writer, translations map[string]map[string]string, locale, namespace string) error {
+	content := make(map[string]string)
+	for key, texts := range translations {
+		if ns, name := SplitNamespacedKey(key); ns == namespace {
+			if text, ok := texts[locale]; ok {
+				content[name] = text
+			}
+		}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(content)
+}
@@ -0,0 +1,135 @@
+package i18n
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParseTranslationFilePath(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected TranslationFile
+		ok       bool
+	}{
+		{path: "de-DE.json", expected: TranslationFile{Path: "de-DE.json", Locale: "de-DE"}, ok: true},
+		{path: "billing/de-DE.json", expected: TranslationFile{Path: "billing/de-DE.json", Locale: "de-DE", Namespace: "billing"}, ok: true},
+		{path: "bots/billing.de-DE.json", expected: TranslationFile{Path: "bots/billing.de-DE.json", Locale: "de-DE", Namespace: "bots/billing"}, ok: true},
+		{path: "de-DE@formal.json", expected: TranslationFile{Path: "de-DE@formal.json", Locale: "de-DE@formal"}, ok: true},
+		{path: "zh-Hans-CN.json", expected: TranslationFile{Path: "zh-Hans-CN.json", Locale: "zh-Hans-CN"}, ok: true},
+		{path: "de.json", expected: TranslationFile{Path: "de.json", Locale: "de"}, ok: true},
+		{path: "README.md"},
+		{path: "billing/.json"},
+		{path: "package.json"},
+		{path: "web/tsconfig.json"},
+		{path: "de-DE@.json"},
+		{path: "de--DE.json"},
+		{path: "DE-de.json"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			file, ok := ParseTranslationFilePath(tc.path)
+			if ok != tc.ok || file != tc.expected {
+				t.Errorf("Expected %+v, %v, got %+v, %v", tc.expected, tc.ok, file, ok)
+			}
+		})
+	}
+}
+
+func TestLoadFS(t *testing.T) {
+	// Prepare test data
+	fsys := fstest.MapFS{
+		"en-US.json":              {Data: []byte(`{"title": "Title", "menu": {"settings": "Settings"}}`)},
+		"de-DE.json":              {Data: []byte(`{"title": "Titel", "verb\u0004open": "Öffnen"}`)},
+		"billing/en-US.json":      {Data: []byte(`{"title": "Billing"}`)},
+		"bots/billing.en-US.json": {Data: []byte(`{"title": "Billing bot"}`)},
+		"README.md":               {Data: []byte(`not a translation file`)},
+		"package.json":            {Data: []byte(`{"name": "frontend", "private": true}`)},
+	}
+
+	translations, err := LoadFS(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]map[string]string{
+		"title":                                {"en-US": "Title", "de-DE": "Titel"},
+		"menu.settings":                        {"en-US": "Settings"},
+		ContextKey("open", "verb"):             {"de-DE": "Öffnen"},
+		NamespacedKey("billing", "title"):      {"en-US": "Billing"},
+		NamespacedKey("bots/billing", "title"): {"en-US": "Billing bot"},
+	}
+	if !reflect.DeepEqual(translations, expected) {
+		t.Errorf("Expected %v, got %v", expected, translations)
+	}
+}
+
+// unreadableFS fails to read files listed by the embedded fstest.MapFS
+type unreadableFS struct {
+	fstest.MapFS
+}
+
+func (unreadableFS) ReadFile(string) ([]byte, error) {
+	return nil, fs.ErrPermission
+}
+
+func TestLoadFS_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fsys     fs.FS
+		expected error
+	}{
+		{name: "Malformed JSON", fsys: fstest.MapFS{"en-US.json": {Data: []byte(`{`)}}, expected: ErrInvalidTranslationFile},
+		{name: "Not a string", fsys: fstest.MapFS{"en-US.json": {Data: []byte(`{"count": 1}`)}}, expected: ErrInvalidTranslationFile},
+		{name: "Nested not a string", fsys: fstest.MapFS{"en-US.json": {Data: []byte(`{"menu": {"count": 1}}`)}}, expected: ErrInvalidTranslationFile},
+		{name: "Duplicate", fsys: fstest.MapFS{"en-US.json": {Data: []byte(`{"menu.title": "A", "menu": {"title": "B"}}`)}}, expected: ErrDuplicateTranslation},
+		{name: "Unreadable", fsys: unreadableFS{fstest.MapFS{"en-US.json": {Data: []byte(`{}`)}}}, expected: fs.ErrPermission},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := LoadFS(tc.fsys); !errors.Is(err, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "billing"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "billing", "fr-FR.json"), []byte(`{"title": "Facturation"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	translations, err := LoadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := translations[NamespacedKey("billing", "title")]["fr-FR"]; s != "Facturation" {
+		t.Errorf("Unexpected translation: %q", s)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	// Prepare test data
+	translations := map[string]map[string]string{
+		"title":                           {"en-US": "Title", "de-DE": "Titel"},
+		NamespacedKey("billing", "title"): {"de-DE": "Abrechnung"},
+		NamespacedKey("billing", "total"): {"en-US": "Total"},
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteJSON(&buffer, translations, "de-DE", "billing"); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "{\n  \"title\": \"Abrechnung\"\n}\n"; buffer.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buffer.String())
+	}
+}
@@ -0,0 +1,106 @@
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// LocalizedError is an error carrying a translation key & arguments that is translated only when shown to a user
+type LocalizedError struct {
+	Key   string
+	Args  []any
+	Cause error
+}
+
+var _ error = (*LocalizedError)(nil)
+
+// defaultErrorTranslator renders LocalizedError.Error(), see SetDefaultErrorTranslator
+var defaultErrorTranslator SingleLocaleTranslator
+
+// SetDefaultErrorTranslator sets translator used by LocalizedError.Error(), usually to the default locale.
+// Should be called at initialization, without it Error() renders key & arguments.
+func SetDefaultErrorTranslator(t SingleLocaleTranslator) {
+	defaultErrorTranslator = t
+}
+
+// NewLocalizedError creates LocalizedError
+func NewLocalizedError(key string, args ...any) *LocalizedError {
+	return &LocalizedError{Key: key, Args: args}
+}
+
+// WrapLocalizedError creates LocalizedError with a cause
+func WrapLocalizedError(cause error, key string, args ...any) *LocalizedError {
+	return &LocalizedError{Key: key, Args: args, Cause: cause}
+}
+
+// Error returns text in the default locale (see SetDefaultErrorTranslator) for logs
+func (e *LocalizedError) Error() string {
+	var s string
+	if t := defaultErrorTranslator; t != nil {
+		var err error
+		if s, err = t.TranslateE(e.Key, e.Args...); err != nil {
+			s = ""
+		}
+	}
+	if s == "" {
+		s = e.Key
+		if len(e.Args) > 0 {
+			s += fmt.Sprintf("(args=%+v)", e.Args)
+		}
+	}
+	if e.Cause != nil {
+		return s + ": " + e.Cause.Error()
+	}
+	return s
+}
+
+// Localize renders the error with the translator, falls back to the key if there is no translation.
+// The cause is not included as it's not meant for users. Localizer arguments, e.g. Message, are localized as well.
+func (e *LocalizedError) Localize(t SingleLocaleTranslator) string {
+	if s, err := t.TranslateE(e.Key, localizeArgs(t, e.Args)...); err == nil || errors.Is(err, ErrBadArgs) {
+		return s
+	}
+	return e.Key
+}
+
+// Unwrap returns the cause
+func (e *LocalizedError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is a LocalizedError with the same key,
+// so localized errors created at package level can be used as sentinels
+func (e *LocalizedError) Is(target error) bool {
+	t, ok := target.(*LocalizedError)
+	return ok && t.Key == e.Key
+}
+
+type localizedErrorJSON struct {
+	Key  string `json:"key"`
+	Args []any  `json:"args,omitempty"`
+}
+
+// MarshalJSON marshals key & args so API clients can translate the error
+func (e *LocalizedError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(localizedErrorJSON{Key: e.Key, Args: e.Args})
+}
+
+// UnmarshalJSON unmarshals key & args
+func (e *LocalizedError) UnmarshalJSON(data []byte) error {
+	var v localizedErrorJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	e.Key, e.Args = v.Key, v.Args
+	return nil
+}
+
+// LocalizeError renders err for a user: LocalizedError in the chain is translated, other errors are returned as is
+func LocalizeError(err error, t SingleLocaleTranslator) string {
+	var localized *LocalizedError
+	if errors.As(err, &localized) {
+		return localized.Localize(t)
+	}
+	return err.Error()
+}
@@ -0,0 +1,116 @@
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestLocalizedError_Error(t *testing.T) {
+	// Prepare test data
+	translator := NewSingleMapTranslator(LocaleEnUS, NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"not_enough_funds": {"en-US": "Not enough funds: %d needed"},
+	}))
+	defer SetDefaultErrorTranslator(nil)
+
+	err := NewLocalizedError("not_enough_funds", 10)
+	if s := err.Error(); s != "not_enough_funds(args=[10])" {
+		t.Errorf("Expected key & args without default translator, got %q", s)
+	}
+	if s := NewLocalizedError("unknown").Error(); s != "unknown" {
+		t.Errorf("Expected key without args, got %q", s)
+	}
+
+	SetDefaultErrorTranslator(translator)
+	if s := err.Error(); s != "Not enough funds: 10 needed" {
+		t.Errorf("Expected default locale text, got %q", s)
+	}
+	if s := WrapLocalizedError(io.EOF, "not_enough_funds", 5).Error(); s != "Not enough funds: 5 needed: EOF" {
+		t.Errorf("Expected text with cause, got %q", s)
+	}
+	if s := NewLocalizedError("unknown").Error(); s != "unknown" {
+		t.Errorf("Expected key for missing translation, got %q", s)
+	}
+}
+
+func TestLocalizedError_Localize(t *testing.T) {
+	// Prepare test data
+	translator := NewSingleMapTranslator(LocaleUkUA, NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"not_enough_funds": {"uk-UA": "Недостатньо коштів: потрібно %d"},
+		"invalid_field":    {"uk-UA": "Невірне поле: %s"},
+		"field_email":      {"uk-UA": "пошта"},
+	}))
+
+	err := WrapLocalizedError(io.EOF, "not_enough_funds", 10)
+	if s := err.Localize(translator); s != "Недостатньо коштів: потрібно 10" {
+		t.Errorf("Unexpected localized text: %q", s)
+	}
+	if s := NewLocalizedError("invalid_field", NewMessage("field_email", "email")).Localize(translator); s != "Невірне поле: пошта" {
+		t.Errorf("Expected Message argument to be localized, got %q", s)
+	}
+	if s := NewLocalizedError("unknown").Localize(translator); s != "unknown" {
+		t.Errorf("Expected key for missing translation, got %q", s)
+	}
+}
+
+func TestLocalizeError(t *testing.T) {
+	// Prepare test data
+	translator := NewSingleMapTranslator(LocaleUkUA, NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"not_enough_funds": {"uk-UA": "Недостатньо коштів: потрібно %d"},
+	}))
+
+	wrapped := fmt.Errorf("payment failed: %w", NewLocalizedError("not_enough_funds", 3))
+	if s := LocalizeError(wrapped, translator); s != "Недостатньо коштів: потрібно 3" {
+		t.Errorf("Unexpected localized text: %q", s)
+	}
+	if s := LocalizeError(io.EOF, translator); s != "EOF" {
+		t.Errorf("Expected plain error text, got %q", s)
+	}
+}
+
+func TestLocalizedError_IsAs(t *testing.T) {
+	errNotEnoughFunds := NewLocalizedError("not_enough_funds")
+	err := fmt.Errorf("charge: %w", WrapLocalizedError(io.ErrUnexpectedEOF, "not_enough_funds", 10))
+
+	if !errors.Is(err, errNotEnoughFunds) {
+		t.Error("Expected errors.Is to match localized error with the same key")
+	}
+	if errors.Is(err, NewLocalizedError("other")) {
+		t.Error("Expected errors.Is not to match localized error with another key")
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Error("Expected errors.Is to match the cause")
+	}
+	var localized *LocalizedError
+	if !errors.As(err, &localized) || localized.Args[0] != 10 {
+		t.Errorf("Expected errors.As to find localized error, got %v", localized)
+	}
+}
+
+func TestLocalizedError_JSON(t *testing.T) {
+	data, err := json.Marshal(WrapLocalizedError(io.EOF, "not_enough_funds", 10, "USD"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := `{"key":"not_enough_funds","args":[10,"USD"]}`; string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	var decoded LocalizedError
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded.Key != "not_enough_funds" || len(decoded.Args) != 2 || decoded.Args[1] != "USD" {
+		t.Errorf("Unexpected decoded error: %+v", decoded)
+	}
+	if err = json.Unmarshal([]byte(`[]`), &decoded); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+
+	if data, _ = json.Marshal(NewLocalizedError("oops")); string(data) != `{"key":"oops"}` {
+		t.Errorf("Expected args to be omitted, got %s", data)
+	}
+}
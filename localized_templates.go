@@ -0,0 +1,45 @@
+package i18n
+
+import (
+	"html/template"
+	"sync"
+)
+
+// LocalizedTemplates clones a parsed template per locale with TemplateFuncs bound to the locale
+// and caches the clones. It is safe for concurrent use.
+type LocalizedTemplates struct {
+	base       *template.Template
+	translator Translator
+	mutex      sync.Mutex
+	clones     sync.Map // locale code5 => *template.Template
+}
+
+// NewLocalizedTemplates creates LocalizedTemplates. The base template should be parsed with
+// .Funcs(TemplateFuncs(nil)) and must not be executed as html/template can not clone executed templates.
+func NewLocalizedTemplates(base *template.Template, translator Translator) *LocalizedTemplates {
+	return &LocalizedTemplates{base: base, translator: translator}
+}
+
+// Get returns template for the locale
+func (lt *LocalizedTemplates) Get(locale Locale) (*template.Template, error) {
+	if tmpl, ok := lt.clones.Load(locale.Code5); ok {
+		return tmpl.(*template.Template), nil
+	}
+	return lt.clone(locale)
+}
+
+// clone clones the base template for the locale unless a concurrent call has done it already
+func (lt *LocalizedTemplates) clone(locale Locale) (*template.Template, error) {
+	lt.mutex.Lock() // html/template does not allow concurrent cloning
+	defer lt.mutex.Unlock()
+	if tmpl, ok := lt.clones.Load(locale.Code5); ok {
+		return tmpl.(*template.Template), nil
+	}
+	tmpl, err := lt.base.Clone()
+	if err != nil {
+		return nil, err
+	}
+	tmpl.Funcs(TemplateFuncs(NewSingleMapTranslator(locale, lt.translator)))
+	lt.clones.Store(locale.Code5, tmpl)
+	return tmpl, nil
+}
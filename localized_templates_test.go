@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"context"
+	"html/template"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLocalizedTemplates_Get(t *testing.T) {
+	// Prepare test data
+	translator := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"welcome": {
+			"en-US": "Welcome, %s!",
+			"de-DE": "Willkommen, %s!",
+		},
+	})
+	base := template.Must(template.New("page").Funcs(TemplateFuncs(nil)).Parse(`<p lang="{{lang}}">{{t "welcome" .}}</p>`))
+	templates := NewLocalizedTemplates(base, translator)
+
+	testCases := []struct {
+		locale   Locale
+		expected string
+	}{
+		{LocaleEnUS, `<p lang="en-US">Welcome, &lt;Bob&gt;!</p>`},
+		{LocaleDeDE, `<p lang="de-DE">Willkommen, &lt;Bob&gt;!</p>`},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, tc := range testCases {
+				tmpl, err := templates.Get(tc.locale)
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+					return
+				}
+				var sb strings.Builder
+				if err = tmpl.Execute(&sb, "<Bob>"); err != nil {
+					t.Errorf("Unexpected error: %v", err)
+					return
+				}
+				if sb.String() != tc.expected {
+					t.Errorf("Expected %q, got %q", tc.expected, sb.String())
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	first, _ := templates.Get(LocaleDeDE)
+	second, _ := templates.Get(LocaleDeDE)
+	if first != second {
+		t.Error("Expected template clone to be cached")
+	}
+	if concurrent, _ := templates.clone(LocaleDeDE); concurrent != first {
+		t.Error("Expected template cloned by a concurrent call to be reused")
+	}
+}
+
+func TestLocalizedTemplates_Get_ExecutedBase(t *testing.T) {
+	base := template.Must(template.New("page").Funcs(TemplateFuncs(nil)).Parse(`{{lang}}`))
+	_ = base.Execute(&strings.Builder{}, nil) // lang panics with nil translator, but the template is marked as executed
+	if _, err := NewLocalizedTemplates(base, mockTranslator{}).Get(LocaleEnUS); err == nil {
+		t.Error("Expected error for executed base template")
+	}
+}
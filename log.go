@@ -1,6 +1,11 @@
 package i18n
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
 
 type Logger interface {
 	Debugf(c context.Context, format string, args ...any)
@@ -8,22 +13,100 @@ type Logger interface {
 	Warningf(c context.Context, format string, args ...any)
 }
 
+// StructuredLogger is a Logger accepting structured attributes like key, locale & fallback locale,
+// e.g. the one created by NewSlogLogger
+type StructuredLogger interface {
+	Logger
+	LogAttrs(c context.Context, level slog.Level, msg string, attrs ...slog.Attr)
+}
+
+// Names of attributes logged by translators
+const (
+	LogAttrKey            = "key"
+	LogAttrLocale         = "locale"
+	LogAttrFallbackLocale = "fallback_locale"
+)
+
 var log Logger
 
-func warningf(c context.Context, format string, args ...any) {
-	if log != nil {
-		log.Warningf(c, format, args...)
+// SetLogger sets logger used by translators that have no own logger, nil disables logging.
+// Should be called at initialization.
+func SetLogger(logger Logger) {
+	log = logger
+}
+
+// logf logs a formatted message to the logger or the package one if the logger is nil
+func logf(c context.Context, logger Logger, level slog.Level, format string, args ...any) {
+	if logger == nil {
+		if logger = log; logger == nil {
+			return
+		}
+	}
+	switch {
+	case level >= slog.LevelError:
+		logger.Errorf(c, format, args...)
+	case level >= slog.LevelWarn:
+		logger.Warningf(c, format, args...)
+	default:
+		logger.Debugf(c, format, args...)
 	}
 }
 
-func errorf(c context.Context, format string, args ...any) {
-	if log != nil {
-		log.Errorf(c, format, args...)
+// logAttrs logs a message with attributes to the logger or the package one if the logger is nil.
+// Loggers that are not a StructuredLogger get attributes formatted as "msg: key=v1&locale=v2".
+func logAttrs(c context.Context, logger Logger, level slog.Level, msg string, attrs ...slog.Attr) {
+	if logger == nil {
+		if logger = log; logger == nil {
+			return
+		}
+	}
+	if structured, ok := logger.(StructuredLogger); ok {
+		structured.LogAttrs(c, level, msg, attrs...)
+		return
+	}
+	var format strings.Builder
+	format.WriteString(msg)
+	args := make([]any, len(attrs))
+	for i, attr := range attrs {
+		if i == 0 {
+			format.WriteString(": ")
+		} else {
+			format.WriteString("&")
+		}
+		format.WriteString(attr.Key + "=%v")
+		args[i] = attr.Value.Any()
 	}
+	logf(c, logger, level, format.String(), args...)
+}
+
+// NewSlogLogger creates StructuredLogger writing to the slog logger, nil means slog.Default()
+func NewSlogLogger(logger *slog.Logger) StructuredLogger {
+	return slogLogger{logger: logger}
 }
 
-func debugf(c context.Context, format string, args ...any) {
-	if log != nil {
-		log.Debugf(c, format, args...)
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l slogLogger) target() *slog.Logger {
+	if l.logger == nil {
+		return slog.Default()
 	}
+	return l.logger
+}
+
+func (l slogLogger) Debugf(c context.Context, format string, args ...any) {
+	l.target().DebugContext(c, fmt.Sprintf(format, args...))
+}
+
+func (l slogLogger) Errorf(c context.Context, format string, args ...any) {
+	l.target().ErrorContext(c, fmt.Sprintf(format, args...))
+}
+
+func (l slogLogger) Warningf(c context.Context, format string, args ...any) {
+	l.target().WarnContext(c, fmt.Sprintf(format, args...))
+}
+
+func (l slogLogger) LogAttrs(c context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	l.target().LogAttrs(c, level, msg, attrs...)
 }
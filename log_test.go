@@ -1,7 +1,11 @@
 package i18n
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"log/slog"
+	"strings"
 	"testing"
 )
 
@@ -32,7 +36,7 @@ func (m *mockLogger) Warningf(_ context.Context, format string, args ...any) {
 	m.lastArgs = args
 }
 
-func TestLoggingFunctions(t *testing.T) {
+func TestLogf(t *testing.T) {
 	// Save the original logger
 	originalLogger := log
 	defer func() {
@@ -40,62 +44,133 @@ func TestLoggingFunctions(t *testing.T) {
 		log = originalLogger
 	}()
 
-	// Create a mock logger
-	mock := &mockLogger{}
-	log = mock
-
 	ctx := context.Background()
 	format := "test %s"
 	args := []any{"message"}
 
-	// Test debugf
-	debugf(ctx, format, args...)
-	if !mock.debugCalled {
-		t.Error("Expected debugf to call log.Debugf")
-	}
-	if mock.lastFormat != format {
-		t.Errorf("Expected format to be %q, got %q", format, mock.lastFormat)
+	testCases := []struct {
+		name   string
+		level  slog.Level
+		called func(m *mockLogger) bool
+	}{
+		{name: "Debug", level: slog.LevelDebug, called: func(m *mockLogger) bool { return m.debugCalled }},
+		{name: "Warning", level: slog.LevelWarn, called: func(m *mockLogger) bool { return m.warningCalled }},
+		{name: "Error", level: slog.LevelError, called: func(m *mockLogger) bool { return m.errorCalled }},
 	}
-	if len(mock.lastArgs) != len(args) || mock.lastArgs[0] != args[0] {
-		t.Errorf("Expected args to be %v, got %v", args, mock.lastArgs)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &mockLogger{}
+			log = mock
+			logf(ctx, nil, tc.level, format, args...)
+			if !tc.called(mock) {
+				t.Errorf("Expected package logger to be called for level %v", tc.level)
+			}
+			if mock.lastFormat != format {
+				t.Errorf("Expected format to be %q, got %q", format, mock.lastFormat)
+			}
+			if len(mock.lastArgs) != len(args) || mock.lastArgs[0] != args[0] {
+				t.Errorf("Expected args to be %v, got %v", args, mock.lastArgs)
+			}
+
+			own := &mockLogger{}
+			logf(ctx, own, tc.level, format, args...)
+			if !tc.called(own) {
+				t.Errorf("Expected own logger to be called for level %v", tc.level)
+			}
+		})
 	}
 
-	// Reset mock
-	mock = &mockLogger{}
-	log = mock
+	// Test with nil logger, should not panic
+	log = nil
+	logf(ctx, nil, slog.LevelError, format, args...)
+	logAttrs(ctx, nil, slog.LevelError, "test", slog.String(LogAttrKey, "hello"))
+}
 
-	// Test errorf
-	errorf(ctx, format, args...)
-	if !mock.errorCalled {
-		t.Error("Expected errorf to call log.Errorf")
+func TestLogAttrs(t *testing.T) {
+	mock := &mockLogger{}
+	logAttrs(context.Background(), mock, slog.LevelWarn, "Translation not found",
+		slog.String(LogAttrKey, "hello"), slog.String(LogAttrLocale, "de-DE"))
+	if !mock.warningCalled {
+		t.Fatal("Expected logger to be called")
 	}
-	if mock.lastFormat != format {
-		t.Errorf("Expected format to be %q, got %q", format, mock.lastFormat)
+	if expected := "Translation not found: key=%v&locale=%v"; mock.lastFormat != expected {
+		t.Errorf("Expected format %q, got %q", expected, mock.lastFormat)
 	}
-	if len(mock.lastArgs) != len(args) || mock.lastArgs[0] != args[0] {
-		t.Errorf("Expected args to be %v, got %v", args, mock.lastArgs)
+	if expected := []any{"hello", "de-DE"}; fmt.Sprint(mock.lastArgs) != fmt.Sprint(expected) {
+		t.Errorf("Expected args %v, got %v", expected, mock.lastArgs)
 	}
+}
 
-	// Reset mock
-	mock = &mockLogger{}
-	log = mock
+func TestSetLogger(t *testing.T) {
+	originalLogger := log
+	defer SetLogger(originalLogger)
 
-	// Test warningf
-	warningf(ctx, format, args...)
+	mock := &mockLogger{}
+	SetLogger(mock)
+	translator := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"hello": {"en-US": "Hello"},
+	})
+	translator.Translate("hello", "de-DE")
 	if !mock.warningCalled {
-		t.Error("Expected warningf to call log.Warningf")
+		t.Fatal("Expected package logger to be called")
 	}
-	if mock.lastFormat != format {
-		t.Errorf("Expected format to be %q, got %q", format, mock.lastFormat)
+	if expected := "Translation not found by key & locale: key=%v&locale=%v"; mock.lastFormat != expected {
+		t.Errorf("Unexpected format: %q", mock.lastFormat)
 	}
-	if len(mock.lastArgs) != len(args) || mock.lastArgs[0] != args[0] {
-		t.Errorf("Expected args to be %v, got %v", args, mock.lastArgs)
+
+	own := &mockLogger{}
+	mock.warningCalled = false
+	translator = NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{}, WithLogger(own))
+	translator.Translate("hello", "de-DE")
+	if !own.warningCalled || mock.warningCalled {
+		t.Error("Expected only own logger of the translator to be called")
+	}
+	if expected := []any{"hello", "de-DE", "en-US"}; fmt.Sprint(own.lastArgs) != fmt.Sprint(expected) {
+		t.Errorf("Expected args %v, got %v", expected, own.lastArgs)
 	}
+}
 
-	// Test with nil logger
-	log = nil
-	// These should not panic
-	debugf(ctx, format, args...)
-	errorf(ctx, format, args...)
-	warningf(ctx, format, args...)
+func TestNewSlogLogger(t *testing.T) {
+	// Prepare test data
+	var buffer bytes.Buffer
+	handler := slog.NewTextHandler(&buffer, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})
+	logger := NewSlogLogger(slog.New(handler))
+	translator := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{}, WithLogger(logger))
+
+	translator.Translate("hello", "de-DE")
+	logger.Debugf(context.Background(), "debug %v", 1)
+	logger.Warningf(context.Background(), "warning %v", 2)
+	logger.Errorf(context.Background(), "error %v", 3)
+
+	expected := `level=WARN msg="Translation not found by key & locale" key=hello locale=de-DE
+level=WARN msg="Translation not found for default locale" key=hello locale=de-DE fallback_locale=en-US
+level=DEBUG msg="debug 1"
+level=WARN msg="warning 2"
+level=ERROR msg="error 3"
+`
+	if buffer.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, buffer.String())
+	}
+}
+
+func TestNewSlogLogger_default(t *testing.T) {
+	// Prepare test data
+	var buffer bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buffer, nil)))
+	defer slog.SetDefault(defaultLogger)
+
+	NewSlogLogger(nil).Warningf(context.Background(), "warning %v", 1)
+	if !strings.Contains(buffer.String(), `msg="warning 1"`) {
+		t.Errorf("Expected message to be logged by slog.Default(), got %q", buffer.String())
+	}
 }
@@ -0,0 +1,24 @@
+package i18n
+
+// LookupResult describes where the text of a key comes from
+type LookupResult struct {
+	Key          string   // looked up key, e.g. with a namespace
+	Locale       string   // requested locale
+	Text         string   // raw text of the message, the key if not found
+	ServedLocale string   // locale of the text, empty if not found
+	Found        bool     // false if there is no translation and the key is served as is
+	FallbackPath []string // locales looked up in order, the last one is ServedLocale if found
+	Source       string   // name of the source of the text, e.g. Layer.Name
+}
+
+// Fallback reports whether the text was found for another locale than the requested one
+func (r LookupResult) Fallback() bool {
+	return r.Found && baseLocale(r.ServedLocale) != baseLocale(r.Locale)
+}
+
+func notFoundResult(key, locale string, fallbackPath ...string) LookupResult {
+	if len(fallbackPath) == 0 {
+		fallbackPath = []string{locale}
+	}
+	return LookupResult{Key: key, Locale: locale, Text: key, FallbackPath: fallbackPath}
+}
@@ -0,0 +1,131 @@
+package i18n
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestMapTranslator_Lookup(t *testing.T) {
+	// Prepare test data
+	translator := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"bye":  {"en-US": "Bye", "de-DE": "Tschüss", "de-DE@formal": "Auf Wiedersehen"},
+		"cta":  {"en-US": "Sign up"},
+		"menu": {"fr-FR": "Menu"},
+	}, WithSourceName("defaults"))
+
+	testCases := []struct {
+		name     string
+		key      string
+		locale   string
+		expected LookupResult
+		fallback bool
+	}{
+		{name: "Found", key: "bye", locale: "de-DE", expected: LookupResult{Key: "bye", Locale: "de-DE", Text: "Tschüss", ServedLocale: "de-DE", Found: true, FallbackPath: []string{"de-DE"}, Source: "defaults"}},
+		{name: "Variant", key: "bye", locale: "de-DE@formal", expected: LookupResult{Key: "bye", Locale: "de-DE@formal", Text: "Auf Wiedersehen", ServedLocale: "de-DE@formal", Found: true, FallbackPath: []string{"de-DE@formal"}, Source: "defaults"}},
+		{name: "Default variant", key: "bye", locale: "de-DE@informal", expected: LookupResult{Key: "bye", Locale: "de-DE@informal", Text: "Tschüss", ServedLocale: "de-DE", Found: true, FallbackPath: []string{"de-DE@informal", "de-DE"}, Source: "defaults"}},
+		{name: "Fallback", key: "cta", locale: "de-DE@formal", expected: LookupResult{Key: "cta", Locale: "de-DE@formal", Text: "Sign up", ServedLocale: "en-US", Found: true, FallbackPath: []string{"de-DE@formal", "de-DE", "en-US"}, Source: "defaults"}, fallback: true},
+		{name: "Not found for locale", key: "menu", locale: "en-US", expected: LookupResult{Key: "menu", Locale: "en-US", Text: "menu", FallbackPath: []string{"en-US"}}},
+		{name: "Unknown key", key: "unknown", locale: "de-DE", expected: LookupResult{Key: "unknown", Locale: "de-DE", Text: "unknown", FallbackPath: []string{"de-DE", "en-US"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := translator.Lookup(tc.key, tc.locale)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, result)
+			}
+			if result.Fallback() != tc.fallback {
+				t.Errorf("Expected Fallback() to return %v", tc.fallback)
+			}
+		})
+	}
+}
+
+func TestMapTranslator_Lookup_emptyDefaultLocale(t *testing.T) {
+	// Prepare test data
+	translator := NewMapTranslator(context.Background(), "", map[string]map[string]string{
+		"cta": {"en-US": "Sign up"},
+	})
+
+	result := translator.Lookup("cta", "de-DE")
+	if !result.Found || result.ServedLocale != "en-US" || !reflect.DeepEqual(result.FallbackPath, []string{"de-DE", "en-US"}) {
+		t.Errorf("Expected fallback to en-US, got %+v", result)
+	}
+}
+
+func TestLookup_Wrappers(t *testing.T) {
+	// Prepare test data
+	ctx := context.Background()
+	base := NewMapTranslator(ctx, "en-US", map[string]map[string]string{
+		NamespacedKey("billing", "title"): {"en-US": "Billing", "de-DE": "Abrechnung"},
+		"Open":                            {"de-DE": "Öffnen"},
+	})
+	layered := NewLayeredTranslator(ctx, []Layer{
+		{Name: "tenant", Translator: NewMapTranslator(ctx, "en-US", map[string]map[string]string{NamespacedKey("billing", "title"): {"en-US": "Acme billing"}})},
+		{Name: "defaults", Translator: base},
+	})
+
+	testCases := []struct {
+		name         string
+		translator   Translator
+		key          string
+		text         string
+		servedLocale string
+		found        bool
+		path         []string
+		source       string
+	}{
+		{name: "Namespace", translator: NewNamespaceTranslator(base, "billing"), key: "title", text: "Abrechnung", servedLocale: "de-DE", found: true, path: []string{"de-DE"}},
+		{name: "Layered", translator: layered, key: NamespacedKey("billing", "title"), text: "Abrechnung", servedLocale: "de-DE", found: true, path: []string{"de-DE", "en-US", "de-DE"}, source: "defaults"},
+		{name: "Layered not found", translator: layered, key: "unknown", text: "unknown", path: []string{"de-DE", "en-US", "de-DE", "en-US"}},
+		{name: "Source text", translator: NewSourceTextTranslator(base, "en-US"), key: "Open", text: "Öffnen", servedLocale: "de-DE", found: true, path: []string{"de-DE"}},
+		{name: "Source text fallback", translator: NewSourceTextTranslator(keysTranslator{}, "en-US"), key: "Close", text: "Close", servedLocale: "en-US", found: true, path: []string{"de-DE", "en-US"}},
+		{name: "Instrumented", translator: NewInstrumentedTranslator(base, NewTranslationMetrics()), key: "Open", text: "Öffnen", servedLocale: "de-DE", found: true, path: []string{"de-DE"}},
+		{name: "Keys", translator: keysTranslator{}, key: "Open", text: "Open", path: []string{"de-DE"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := tc.translator.Lookup(tc.key, "de-DE")
+			if result.Text != tc.text || result.ServedLocale != tc.servedLocale || result.Found != tc.found || result.Source != tc.source {
+				t.Errorf("Unexpected result: %+v", result)
+			}
+			if !reflect.DeepEqual(result.FallbackPath, tc.path) {
+				t.Errorf("Expected fallback path %v, got %v", tc.path, result.FallbackPath)
+			}
+		})
+	}
+}
+
+func TestSingleLocaleTranslator_Lookup(t *testing.T) {
+	// Prepare test data
+	ctx := context.Background()
+	primary := NewSingleMapTranslator(LocaleDeDE, NewMapTranslator(ctx, "de-DE", map[string]map[string]string{
+		"empty": {"de-DE": ""},
+		"hello": {"de-DE": "Hallo"},
+	}), WithFormalityVariant(FormalityFormal))
+	backup := NewSingleMapTranslator(LocaleEnUS, NewMapTranslator(ctx, "en-US", map[string]map[string]string{
+		"empty": {"en-US": "Empty"},
+		"bye":   {"en-US": "Bye"},
+	}))
+	translator := NewSingleLocaleTranslatorWithBackup(primary, backup)
+
+	if result := primary.Lookup("hello"); !result.Found || result.Locale != "de-DE@formal" || result.ServedLocale != "de-DE" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if result := translator.Lookup("bye"); !result.Found || result.Locale != "de-DE@formal" || !result.Fallback() ||
+		!reflect.DeepEqual(result.FallbackPath, []string{"de-DE@formal", "de-DE", "en-US"}) {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if result := translator.Lookup("hello"); !result.Found || result.ServedLocale != "de-DE" || result.Fallback() {
+		t.Errorf("Expected primary result, got %+v", result)
+	}
+	// An empty translation is a translation rather than a missing one
+	if s := translator.Translate("empty"); s != "" {
+		t.Errorf("Expected empty translation of primary translator, got %q", s)
+	}
+	if s := translator.TranslateWithMap("bye", nil); s != "Bye" {
+		t.Errorf("Expected backup translation, got %q", s)
+	}
+}
@@ -1,36 +1,56 @@
 package i18n
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"html/template"
+	"log/slog"
 	"strings"
 )
 
 type mapTranslator struct {
-	c                 context.Context
-	defaultLocale     string
-	translations      map[string]map[string]string
-	templatesByLocale map[string]*template.Template
+	c             context.Context
+	defaultLocale string
+	translations  map[string]map[string]string
+	messages      *messageCache
+	engine        MessageEngine
+	keyEngines    map[string]MessageEngine
+	funcs         map[string]any
+
+	missingObserver MissingObserver
+	logger          Logger
+	source          string
 }
 
 func (t mapTranslator) TranslateWithMap(key, locale string, args map[string]string) string {
 	s := t._translate(true, key, locale)
-	return placeMapValues(s, args)
+	return placeMapValues(locale, s, args)
 }
 
 // NewMapTranslator creates new map translator
-func NewMapTranslator(c context.Context, defaultLocale string, translations map[string]map[string]string) Translator {
-	return mapTranslator{
-		c:                 c,
-		defaultLocale:     defaultLocale,
-		translations:      translations,
-		templatesByLocale: make(map[string]*template.Template),
+func NewMapTranslator(c context.Context, defaultLocale string, translations map[string]map[string]string, options ...MapTranslatorOption) Translator {
+	t := mapTranslator{
+		c:             c,
+		defaultLocale: defaultLocale,
+		translations:  translations,
+		messages:      new(messageCache),
+	}
+	for _, option := range options {
+		option(&t)
 	}
+	return t
 }
 
-func placeMapValues(s string, args map[string]string) string {
+// placeMapValues replaces {key} placeholders with args and appends " $EXTRA(key)" for unused args.
+// Messages with placeholders of formats known to FormatMessage, e.g. {user.gender, select, ...} or {user, case, genitive},
+// are rendered as by FormatMessage ignoring unused args, other braces like {x, y} are left as is.
+func placeMapValues(locale, s string, args map[string]string) string {
+	if strings.Contains(s, ",") {
+		if segments, errs := parsePlaceholders(s); len(errs) == 0 && hasKnownFormats(segments) {
+			s, _ = renderSegments(locale, segments, args)
+			return s
+		}
+	}
 	for k, v := range args {
 		if placeholder := "{" + k + "}"; strings.Contains(s, placeholder) {
 			s = strings.ReplaceAll(s, placeholder, v)
@@ -42,44 +62,186 @@ func placeMapValues(s string, args map[string]string) string {
 }
 
 func (t mapTranslator) _translate(warn bool, key, locale string, args ...any) string {
-	s, found := t.translations[key][locale]
-	if !found {
-		if warn {
-			warningf(t.c, "Translation not found by key & locale: key=%v&locale=%v", key, locale)
+	s, err := t.translate(warn, key, locale, args...)
+	if err == nil {
+		return s
+	}
+	var tmplErr *templateError
+	if errors.As(err, &tmplErr) {
+		panic(fmt.Sprintf("Failed to %v template '%v' for locale '%v': %v", tmplErr.action, key, locale, tmplErr.err.Error()))
+	}
+	return s
+}
+
+// lookup returns raw text of a message and the locale it was found for.
+// Variants of a locale like "de-DE@formal" fall back to the locale without the variant.
+// For missing translations the text is what Translate() has always returned: the key or an empty string.
+func (t mapTranslator) lookup(warn bool, key, locale string) (s, servedLocale string, err error) {
+	translations, keyFound := t.translations[key]
+	if s, found := translations[locale]; found {
+		return s, locale, nil
+	}
+	code5 := baseLocale(locale)
+	if code5 != locale {
+		if s, found := translations[code5]; found {
+			return s, code5, nil
 		}
-		if defaultLocale := t.defaultLocale; defaultLocale != locale {
-			if defaultLocale == "" {
-				defaultLocale = "en-US"
-			}
-			if s, found = t.translations[key][defaultLocale]; !found {
-				if warn {
-					warningf(t.c, "Translation not found for default locale: key=%v&locale=%v", key, defaultLocale)
-				}
-				return key
-			}
+	}
+	if warn {
+		logAttrs(t.c, t.logger, slog.LevelWarn, "Translation not found by key & locale",
+			slog.String(LogAttrKey, key), slog.String(LogAttrLocale, locale))
+	}
+	defaultLocale := t.defaultLocale
+	if defaultLocale == code5 {
+		t.observeMissing(warn, key, locale, "", "")
+		return "", "", t.notFoundError(keyFound, key, locale)
+	}
+	if defaultLocale == "" {
+		defaultLocale = "en-US"
+	}
+	if s, found := translations[defaultLocale]; found {
+		t.observeMissing(warn, key, locale, defaultLocale, s)
+		return s, defaultLocale, nil
+	}
+	if warn {
+		logAttrs(t.c, t.logger, slog.LevelWarn, "Translation not found for default locale",
+			slog.String(LogAttrKey, key), slog.String(LogAttrLocale, locale), slog.String(LogAttrFallbackLocale, defaultLocale))
+	}
+	t.observeMissing(warn, key, locale, "", key)
+	return key, "", t.notFoundError(keyFound, key, locale)
+}
+
+func (t mapTranslator) Lookup(key, locale string) LookupResult {
+	s, servedLocale, err := t.lookup(false, key, locale)
+	path := []string{locale}
+	if code5 := baseLocale(locale); code5 != locale {
+		path = append(path, code5)
+	}
+	if defaultLocale := t.defaultLocale; defaultLocale != baseLocale(locale) {
+		if defaultLocale == "" {
+			defaultLocale = "en-US"
 		}
+		path = append(path, defaultLocale)
 	}
-	if len(args) > 0 {
-		if len(args) == 1 && strings.Contains(s, "}}") && (strings.Contains(s, "{{.") || strings.Contains(s, "{{ .")) {
-			tk := locale + key
-			tmpl, ok := t.templatesByLocale[tk]
-			if !ok {
-				var err error
-				if tmpl, err = template.New(key).Parse(s); err != nil {
-					panic(fmt.Sprintf("Failed to parse template '%v' for locale '%v': %v", key, locale, err.Error()))
-				}
-				t.templatesByLocale[tk] = tmpl
-			}
-			var buffer bytes.Buffer
-			if err := tmpl.Execute(&buffer, args[0]); err != nil {
-				panic(fmt.Sprintf("Failed to render template '%v' for locale '%v': %v", key, locale, err.Error()))
-			} else {
-				return buffer.String()
+	if err != nil {
+		return notFoundResult(key, locale, path...)
+	}
+	for i, l := range path {
+		if l == servedLocale {
+			path = path[:i+1]
+			break
+		}
+	}
+	return LookupResult{Key: key, Locale: locale, Text: s, ServedLocale: servedLocale, Found: true, FallbackPath: path, Source: t.source}
+}
+
+func (t mapTranslator) observeMissing(warn bool, key, locale, servedLocale, text string) {
+	if warn && t.missingObserver != nil {
+		t.missingObserver.ObserveMissing(MissingTranslation{Key: key, Locale: locale, ServedLocale: servedLocale, Text: text})
+	}
+}
+
+// hasKnownFormats tells whether any placeholder has a format supported by FormatMessage, e.g. {user, select, ...}
+func hasKnownFormats(segments []messageSegment) bool {
+	for _, segment := range segments {
+		if segment.placeholder != nil {
+			switch segment.placeholder.format {
+			case "select", "case", "pronoun", "number", "date", "time":
+				return true
 			}
 		}
-		s = fmt.Sprintf(s, args...)
 	}
-	return s
+	return false
+}
+
+// translate returns translated text and an error if translation failed.
+func (t mapTranslator) translate(warn bool, key, locale string, args ...any) (string, error) {
+	s, _, err := t.lookup(warn, key, locale)
+	if err != nil {
+		return s, err
+	}
+	engine := t.engineFor(key)
+	if len(args) == 0 && (engine == EngineAuto || engine == EnginePrintf) {
+		return s, nil
+	}
+	switch m := t.compiled(engine, key, locale, s); engine {
+	case EngineTextTemplate, EngineHTMLTemplate:
+		if len(args) > 1 {
+			return "", &TranslationError{Key: key, Locale: locale, Err: fmt.Errorf("%w: template expects a single argument, got %d", ErrBadArgs, len(args))}
+		}
+		var data any
+		if len(args) == 1 {
+			data = args[0]
+		}
+		return m.execute(key, locale, data)
+	case EnginePlaceholders:
+		var values any = args
+		if len(args) == 1 {
+			values = args[0]
+		}
+		return m.render(key, locale, values)
+	default:
+		if engine == EngineAuto && len(args) == 1 && m.isTemplate() {
+			return m.execute(key, locale, args[0])
+		}
+	}
+	return sprintf(key, locale, s, args...)
+}
+
+// sprintf formats s with args reporting ErrBadArgs instead of silently producing %!verb(...) garbage
+func sprintf(key, locale, s string, args ...any) (string, error) {
+	if len(args) == 0 {
+		return s, nil
+	}
+	formatted := fmt.Sprintf(s, args...)
+	if strings.Contains(formatted, "%!") && !strings.Contains(s, "%!") {
+		return formatted, &TranslationError{Key: key, Locale: locale, Err: fmt.Errorf("%w: %v", ErrBadArgs, formatted)}
+	}
+	return formatted, nil
+}
+
+// formatValues translates a message with named placeholders using the compiled messages cache.
+// Template messages are executed with args as data.
+func (t mapTranslator) formatValues(key, locale string, args any) (string, error) {
+	s, _, err := t.lookup(true, key, locale)
+	if err != nil {
+		return "", err
+	}
+	m := t.compiled(t.engineFor(key), key, locale, s)
+	if m.isTemplate() {
+		return m.execute(key, locale, args)
+	}
+	return m.render(key, locale, args)
+}
+
+func (t mapTranslator) engineFor(key string) MessageEngine {
+	if engine, ok := t.keyEngines[key]; ok {
+		return engine
+	}
+	return t.engine
+}
+
+// compiled returns message compiled for the requested locale, so template functions are bound to it
+func (t mapTranslator) compiled(engine MessageEngine, key, locale, text string) *compiledMessage {
+	if m, ok := t.messages.get(key, locale, text); ok {
+		return m
+	}
+	m := compileMessage(engine, key, text, func() map[string]any {
+		funcs := messageFuncs(t, locale)
+		for name, f := range t.funcs {
+			funcs[name] = f
+		}
+		return funcs
+	})
+	t.messages.put(key, locale, m)
+	return m
+}
+
+func (t mapTranslator) notFoundError(keyFound bool, key, locale string) error {
+	if keyFound {
+		return &TranslationError{Key: key, Locale: locale, Err: ErrLocaleNotFound}
+	}
+	return &TranslationError{Key: key, Locale: locale, Err: ErrKeyNotFound}
 }
 
 func (t mapTranslator) Translate(key, locale string, args ...any) string {
@@ -89,3 +251,13 @@ func (t mapTranslator) Translate(key, locale string, args ...any) string {
 func (t mapTranslator) TranslateNoWarning(key, locale string, args ...any) string {
 	return t._translate(false, key, locale, args...)
 }
+
+// TranslateE translates and returns an error instead of panicking or silently producing bad text.
+// On ErrBadArgs the badly formatted text is returned along with the error, otherwise text is empty on error.
+func (t mapTranslator) TranslateE(key, locale string, args ...any) (string, error) {
+	s, err := t.translate(true, key, locale, args...)
+	if err != nil && !errors.Is(err, ErrBadArgs) {
+		return "", err
+	}
+	return s, err
+}
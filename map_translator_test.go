@@ -2,7 +2,9 @@ package i18n
 
 import (
 	"context"
+	"errors"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -266,11 +268,29 @@ func TestPlaceMapValues(t *testing.T) {
 			args:     map[string]string{"name": "John"},
 			expected: "Hello, World! $EXTRA(name)",
 		},
+		{
+			name:     "Braces with unknown format",
+			s:        "Coordinates {x, y} of {name}",
+			args:     map[string]string{"name": "N"},
+			expected: "Coordinates {x, y} of N",
+		},
+		{
+			name:     "Double braces with unknown format",
+			s:        "{{name}} at {x, y}",
+			args:     map[string]string{"name": "N"},
+			expected: "{N} at {x, y}",
+		},
+		{
+			name:     "Select format",
+			s:        "{{{name}}} {gender, select, female {her} other {their}} team",
+			args:     map[string]string{"name": "N", "gender": "female"},
+			expected: "{N} her team",
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := placeMapValues(tc.s, tc.args)
+			result := placeMapValues("en-US", tc.s, tc.args)
 			if result != tc.expected {
 				t.Errorf("Expected placeMapValues(%q, %v) to return %q, got %q",
 					tc.s, tc.args, tc.expected, result)
@@ -418,3 +438,232 @@ func TestMapTranslator_TemplatePanics(t *testing.T) {
 		translator._translate(true, "template_with_error", "en-US", struct{ Name string }{"World"})
 	})
 }
+
+func TestMapTranslator_TranslateE(t *testing.T) {
+	// Prepare test data
+	ctx := context.Background()
+	translations := map[string]map[string]string{
+		"greeting": {
+			"en-US": "Hello, %s!",
+		},
+		"count": {
+			"es-ES": "Cuenta: %d",
+		},
+		"template": {
+			"en-US": "Hello, {{.Name}}!",
+		},
+		"invalid_template": {
+			"en-US": "Hello, {{.Name}} and {{.Invalid}",
+		},
+		"template_with_error": {
+			"en-US": "Hello, {{.Name}} and {{.NonExistentMethod}}!",
+		},
+	}
+
+	translator := NewMapTranslator(ctx, "en-US", translations)
+
+	testCases := []struct {
+		name     string
+		key      string
+		locale   string
+		args     []any
+		expected string
+		errIs    error
+	}{
+		{
+			name:     "Found",
+			key:      "greeting",
+			locale:   "en-US",
+			args:     []any{"World"},
+			expected: "Hello, World!",
+		},
+		{
+			name:     "Fallback to default locale is not an error",
+			key:      "greeting",
+			locale:   "fr-FR",
+			args:     []any{"Monde"},
+			expected: "Hello, Monde!",
+		},
+		{
+			name:   "Key not found",
+			key:    "nonexistent",
+			locale: "en-US",
+			errIs:  ErrKeyNotFound,
+		},
+		{
+			name:   "Locale not found",
+			key:    "count",
+			locale: "fr-FR",
+			errIs:  ErrLocaleNotFound,
+		},
+		{
+			name:     "Bad args",
+			key:      "count",
+			locale:   "es-ES",
+			args:     []any{"abc"},
+			expected: "Cuenta: %!d(string=abc)",
+			errIs:    ErrBadArgs,
+		},
+		{
+			name:   "Template parse error",
+			key:    "invalid_template",
+			locale: "en-US",
+			args:   []any{struct{ Name string }{"World"}},
+			errIs:  ErrTemplate,
+		},
+		{
+			name:   "Template execution error",
+			key:    "template_with_error",
+			locale: "en-US",
+			args:   []any{struct{ Name string }{"World"}},
+			errIs:  ErrTemplate,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := translator.TranslateE(tc.key, tc.locale, tc.args...)
+			if result != tc.expected {
+				t.Errorf("Expected TranslateE(%q, %q, %v) to return %q, got %q",
+					tc.key, tc.locale, tc.args, tc.expected, result)
+			}
+			if tc.errIs == nil {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.errIs) {
+				t.Errorf("Expected error to match %v, got %v", tc.errIs, err)
+			}
+			var translationErr *TranslationError
+			if !errors.As(err, &translationErr) || translationErr.Key != tc.key || translationErr.Locale != tc.locale {
+				t.Errorf("Expected *TranslationError for key=%v&locale=%v, got %#v", tc.key, tc.locale, err)
+			}
+		})
+	}
+}
+
+func TestMapTranslator_ConcurrentUse(t *testing.T) {
+	// Run with -race to verify translator can be shared by concurrent request handlers
+	translator := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"template": {
+			"en-US": "Hello, {{.Name}}!",
+			"fr-FR": "Bonjour, {{.Name}}!",
+		},
+		"named": {
+			"en-US": "Hello, {name}!",
+		},
+	})
+	locales := []string{"en-US", "fr-FR", "de-DE"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				locale := locales[(i+j)%len(locales)]
+				if s := translator.Translate("template", locale, struct{ Name string }{"World"}); !strings.HasSuffix(s, ", World!") {
+					t.Errorf("Unexpected translation: %q", s)
+					return
+				}
+				if s, err := TranslateWithValues(translator, "named", locale, map[string]any{"name": "World"}); err != nil || s != "Hello, World!" {
+					t.Errorf("Unexpected translation: %q, %v", s, err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestMapTranslator_formatValues(t *testing.T) {
+	// Prepare test data
+	translator := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"named":    {"en-US": "Hello, {name}!"},
+		"template": {"en-US": "Hello, {{.Name}}!"},
+		"broken":   {"en-US": "Hello, {name"},
+	})
+
+	testCases := []struct {
+		name      string
+		key       string
+		expected  string
+		notFound  bool
+		syntaxErr bool
+	}{
+		{name: "Named", key: "named", expected: "Hello, World!"},
+		{name: "Template", key: "template", expected: "Hello, World!"},
+		{name: "Malformed placeholder", key: "broken", expected: "Hello, {name", syntaxErr: true},
+		{name: "Missing", key: "unknown", notFound: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := TranslateWithValues(translator, tc.key, "en-US", struct{ Name string }{"World"})
+			if result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+			if IsNotFound(err) != tc.notFound {
+				t.Errorf("Expected not found %v, got %v", tc.notFound, err)
+			}
+			if errors.Is(err, ErrPlaceholderSyntax) != tc.syntaxErr {
+				t.Errorf("Expected syntax error %v, got %v", tc.syntaxErr, err)
+			}
+		})
+	}
+}
+
+func BenchmarkMapTranslator_Translate(b *testing.B) {
+	// Prepare test data
+	translator := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"plain": {
+			"en-US": "Hello!",
+		},
+		"printf": {
+			"en-US": "Hello, %s!",
+		},
+		"template": {
+			"en-US": "Hello, {{.Name}}!",
+		},
+		"named": {
+			"en-US": "Hello, {name}! You have {count, number} messages.",
+		},
+	})
+	b.Run("plain", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = translator.Translate("plain", "en-US")
+		}
+	})
+	b.Run("printf", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = translator.Translate("printf", "en-US", "World")
+		}
+	})
+	b.Run("template", func(b *testing.B) {
+		b.ReportAllocs()
+		args := struct{ Name string }{"World"}
+		for i := 0; i < b.N; i++ {
+			_ = translator.Translate("template", "en-US", args)
+		}
+	})
+	b.Run("named", func(b *testing.B) {
+		b.ReportAllocs()
+		args := map[string]any{"name": "World", "count": 1234}
+		for i := 0; i < b.N; i++ {
+			_, _ = TranslateWithValues(translator, "named", "en-US", args)
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		b.ReportAllocs()
+		args := struct{ Name string }{"World"}
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = translator.Translate("template", "en-US", args)
+			}
+		})
+	})
+}
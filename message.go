@@ -0,0 +1,92 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Localizer is implemented by values that render themselves with a translator, e.g. Message and *LocalizedError
+type Localizer interface {
+	Localize(t SingleLocaleTranslator) string
+}
+
+// Message is a translatable message that can be defined before a locale is known,
+// e.g. at package init for menus, validation rules or enum tables, and resolved per request.
+type Message struct {
+	Key         string
+	Default     string // source text used if there is no translation, formatted with Args by fmt.Sprintf
+	Args        []any  // a Localizer argument is localized by the same translator
+	Description string // hint for translators
+}
+
+var _ Localizer = Message{}
+var _ Localizer = (*LocalizedError)(nil)
+var _ fmt.Formatter = Message{}
+
+// NewMessage creates Message
+func NewMessage(key, defaultText string) Message {
+	return Message{Key: key, Default: defaultText}
+}
+
+// WithArgs returns a copy of the message with arguments
+func (m Message) WithArgs(args ...any) Message {
+	m.Args = args
+	return m
+}
+
+// WithDescription returns a copy of the message with a description for translators
+func (m Message) WithDescription(description string) Message {
+	m.Description = description
+	return m
+}
+
+// Localize translates the message falling back to the default text if translation is missing or fails to render
+func (m Message) Localize(t SingleLocaleTranslator) string {
+	args := localizeArgs(t, m.Args)
+	if s, err := t.TranslateE(m.Key, args...); err == nil || errors.Is(err, ErrBadArgs) {
+		return s
+	}
+	return m.format(args)
+}
+
+// String returns the default text formatted with arguments
+func (m Message) String() string {
+	return m.format(m.Args)
+}
+
+// Format implements fmt.Formatter so messages can be printed with %s, %v & %q using the default text
+func (m Message) Format(f fmt.State, verb rune) {
+	s := m.String()
+	if verb == 'q' {
+		s = strconv.Quote(s)
+	}
+	_, _ = f.Write([]byte(s))
+}
+
+func (m Message) format(args []any) string {
+	if m.Default == "" {
+		return m.Key
+	}
+	if len(args) == 0 {
+		return m.Default
+	}
+	return fmt.Sprintf(m.Default, args...)
+}
+
+func localizeArgs(t SingleLocaleTranslator, args []any) []any {
+	var localized []any
+	for i, arg := range args {
+		if localizer, ok := arg.(Localizer); ok {
+			if localized == nil {
+				localized = make([]any, len(args))
+				copy(localized, args)
+			}
+			localized[i] = localizer.Localize(t)
+		}
+	}
+	if localized == nil {
+		return args
+	}
+	return localized
+}
@@ -0,0 +1,120 @@
+package i18n
+
+import (
+	htmltemplate "html/template"
+	"io"
+	texttemplate "text/template"
+)
+
+// MessageEngine defines how arguments are placed into a message text
+type MessageEngine int
+
+const (
+	// EngineAuto uses html/template if there is exactly one argument and the text contains {{.Field}},
+	// otherwise fmt.Sprintf is used. This is the default for backward compatibility.
+	EngineAuto MessageEngine = iota
+
+	// EnginePrintf formats messages with fmt.Sprintf
+	EnginePrintf
+
+	// EngineTextTemplate executes messages as text/template, e.g. for plain-text or Telegram messages
+	EngineTextTemplate
+
+	// EngineHTMLTemplate executes messages as html/template that escapes output
+	EngineHTMLTemplate
+
+	// EnginePlaceholders formats messages with named & positional placeholders, see FormatMessage
+	EnginePlaceholders
+)
+
+func (e MessageEngine) String() string {
+	switch e {
+	case EngineAuto:
+		return "auto"
+	case EnginePrintf:
+		return "printf"
+	case EngineTextTemplate:
+		return "text/template"
+	case EngineHTMLTemplate:
+		return "html/template"
+	case EnginePlaceholders:
+		return "placeholders"
+	default:
+		return "unknown"
+	}
+}
+
+// MapTranslatorOption configures translator created by NewMapTranslator
+type MapTranslatorOption func(t *mapTranslator)
+
+// WithMessageEngine sets engine used for all messages of the translator
+func WithMessageEngine(engine MessageEngine) MapTranslatorOption {
+	return func(t *mapTranslator) {
+		t.engine = engine
+	}
+}
+
+// WithKeyEngine sets engine for a specific message overriding the translator engine
+func WithKeyEngine(key string, engine MessageEngine) MapTranslatorOption {
+	return func(t *mapTranslator) {
+		if t.keyEngines == nil {
+			t.keyEngines = make(map[string]MessageEngine)
+		}
+		t.keyEngines[key] = engine
+	}
+}
+
+// WithLogger sets logger of the translator overriding the package one set by SetLogger
+func WithLogger(logger Logger) MapTranslatorOption {
+	return func(t *mapTranslator) {
+		t.logger = logger
+	}
+}
+
+// optionsLogger returns logger set by WithLogger in the options or nil, so translators configured with the options
+// log their own messages to the same logger as translators they create
+func optionsLogger(options []MapTranslatorOption) Logger {
+	var t mapTranslator
+	for _, option := range options {
+		option(&t)
+	}
+	return t.logger
+}
+
+// WithSourceName sets name of the translator reported by Lookup as LookupResult.Source
+func WithSourceName(name string) MapTranslatorOption {
+	return func(t *mapTranslator) {
+		t.source = name
+	}
+}
+
+// WithTemplateFuncs adds functions available to template messages in addition to the shared ones: t, tn, number, date & time
+func WithTemplateFuncs(funcs map[string]any) MapTranslatorOption {
+	return func(t *mapTranslator) {
+		if t.funcs == nil {
+			t.funcs = make(map[string]any, len(funcs))
+		}
+		for name, f := range funcs {
+			t.funcs[name] = f
+		}
+	}
+}
+
+type templateExecutor interface {
+	Execute(w io.Writer, data any) error
+}
+
+func parseTemplate(engine MessageEngine, key, text string, funcs map[string]any) (templateExecutor, error) {
+	if engine == EngineTextTemplate {
+		tmpl, err := texttemplate.New(key).Funcs(funcs).Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		return tmpl, nil
+	}
+	tmpl, err := htmltemplate.New(key).Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
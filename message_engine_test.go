@@ -0,0 +1,189 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMessageEngine_String(t *testing.T) {
+	testCases := []struct {
+		engine   MessageEngine
+		expected string
+	}{
+		{EngineAuto, "auto"},
+		{EnginePrintf, "printf"},
+		{EngineTextTemplate, "text/template"},
+		{EngineHTMLTemplate, "html/template"},
+		{EnginePlaceholders, "placeholders"},
+		{MessageEngine(100), "unknown"},
+	}
+	for _, tc := range testCases {
+		if s := tc.engine.String(); s != tc.expected {
+			t.Errorf("Expected %q, got %q", tc.expected, s)
+		}
+	}
+}
+
+func TestNewMapTranslator_MessageEngines(t *testing.T) {
+	// Prepare test data
+	translations := map[string]map[string]string{
+		"greeting": {
+			"en-US": "Hello, {{.Name}}!",
+		},
+		"printf": {
+			"en-US": "Hello, {{.Name}} and %s!",
+		},
+		"named": {
+			"en-US": "Hello, {name}! You owe {amount, number, currency}",
+			"de-DE": "Hallo, {name}! Sie schulden {amount, number, currency}",
+		},
+		"positional": {
+			"en-US": "{1} follows {0}",
+		},
+		"menu": {
+			"en-US": "{{t \"title\"}}: {{tn \"files\" .Count}} ({{number .Total}})",
+			"uk-UA": "{{t \"title\"}}: {{tn \"files\" .Count}} ({{number .Total}})",
+		},
+		"title": {
+			"en-US": "Files",
+			"uk-UA": "Файли",
+		},
+		"files_one": {
+			"en-US": "%d file",
+			"uk-UA": "%d файл",
+		},
+		"files_few": {
+			"uk-UA": "%d файли",
+		},
+		"files_other": {
+			"en-US": "%d files",
+			"uk-UA": "%d файлів",
+		},
+		"no_args_template": {
+			"en-US": "{{t \"title\"}}",
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		options  []MapTranslatorOption
+		key      string
+		locale   string
+		args     []any
+		expected string
+		errIs    error
+	}{
+		{
+			name:     "Auto uses html/template that escapes output",
+			key:      "greeting",
+			locale:   "en-US",
+			args:     []any{struct{ Name string }{"<b>Bob</b>"}},
+			expected: "Hello, &lt;b&gt;Bob&lt;/b&gt;!",
+		},
+		{
+			name:     "Text template does not escape output",
+			options:  []MapTranslatorOption{WithMessageEngine(EngineTextTemplate)},
+			key:      "greeting",
+			locale:   "en-US",
+			args:     []any{struct{ Name string }{"<b>Bob</b>"}},
+			expected: "Hello, <b>Bob</b>!",
+		},
+		{
+			name:     "Per key engine overrides translator engine",
+			options:  []MapTranslatorOption{WithMessageEngine(EngineTextTemplate), WithKeyEngine("printf", EnginePrintf)},
+			key:      "printf",
+			locale:   "en-US",
+			args:     []any{"Ann"},
+			expected: "Hello, {{.Name}} and Ann!",
+		},
+		{
+			name:     "Template with more than one argument",
+			options:  []MapTranslatorOption{WithMessageEngine(EngineHTMLTemplate)},
+			key:      "greeting",
+			locale:   "en-US",
+			args:     []any{1, 2},
+			expected: "",
+			errIs:    ErrBadArgs,
+		},
+		{
+			name:     "Named placeholders",
+			options:  []MapTranslatorOption{WithMessageEngine(EnginePlaceholders)},
+			key:      "named",
+			locale:   "de-DE",
+			args:     []any{map[string]any{"name": "Max", "amount": 10}},
+			expected: "Hallo, Max! Sie schulden 10,00\u00a0€",
+		},
+		{
+			name:     "Positional placeholders",
+			options:  []MapTranslatorOption{WithKeyEngine("positional", EnginePlaceholders)},
+			key:      "positional",
+			locale:   "en-US",
+			args:     []any{"A", "B"},
+			expected: "B follows A",
+		},
+		{
+			name:     "Missing placeholder",
+			options:  []MapTranslatorOption{WithMessageEngine(EnginePlaceholders)},
+			key:      "named",
+			locale:   "en-US",
+			args:     []any{map[string]any{"name": "Max"}},
+			expected: "Hello, Max! You owe {amount}",
+			errIs:    ErrPlaceholderMissing,
+		},
+		{
+			name:     "Shared functions in English",
+			options:  []MapTranslatorOption{WithKeyEngine("menu", EngineTextTemplate)},
+			key:      "menu",
+			locale:   "en-US",
+			args:     []any{map[string]any{"Count": 1, "Total": 1234}},
+			expected: "Files: 1 file (1,234)",
+		},
+		{
+			name:     "Shared functions bound to requested locale",
+			options:  []MapTranslatorOption{WithKeyEngine("menu", EngineTextTemplate)},
+			key:      "menu",
+			locale:   "uk-UA",
+			args:     []any{map[string]any{"Count": 3, "Total": 1234}},
+			expected: "Файли: 3 файли (1\u00a0234)",
+		},
+		{
+			name:     "Explicit template engine renders without args",
+			options:  []MapTranslatorOption{WithMessageEngine(EngineTextTemplate)},
+			key:      "no_args_template",
+			locale:   "en-US",
+			expected: "Files",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			translator := NewMapTranslator(context.Background(), "en-US", translations, tc.options...)
+			result, err := translator.TranslateE(tc.key, tc.locale, tc.args...)
+			if result != tc.expected {
+				t.Errorf("Expected TranslateE(%q, %q, %v) to return %q, got %q",
+					tc.key, tc.locale, tc.args, tc.expected, result)
+			}
+			if tc.errIs == nil && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			} else if tc.errIs != nil && !errors.Is(err, tc.errIs) {
+				t.Errorf("Expected error to match %v, got %v", tc.errIs, err)
+			}
+		})
+	}
+}
+
+func TestWithTemplateFuncs(t *testing.T) {
+	translations := map[string]map[string]string{
+		"shout": {
+			"en-US": "{{shout .}}",
+		},
+	}
+	translator := NewMapTranslator(context.Background(), "en-US", translations,
+		WithMessageEngine(EngineTextTemplate),
+		WithTemplateFuncs(map[string]any{"shout": func(s string) string { return s + "!" }}),
+	)
+	if result := translator.Translate("shout", "en-US", "hey"); result != "hey!" {
+		t.Errorf("Expected %q, got %q", "hey!", result)
+	}
+}
@@ -0,0 +1,65 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+var (
+	testMenuSettings = NewMessage("menu_settings", "Settings").WithDescription("Main menu item")
+	testFieldEmail   = NewMessage("field_email", "email")
+	testRuleRequired = NewMessage("rule_required", "%s is required")
+)
+
+func TestMessage_Localize(t *testing.T) {
+	// Prepare test data
+	translator := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"menu_settings": {"de-DE": "Einstellungen"},
+		"field_email":   {"de-DE": "E-Mail"},
+		"rule_required": {"de-DE": "%s ist erforderlich"},
+		"greeting":      {"de-DE": "Hallo, {{.Name}}!"},
+	})
+
+	testCases := []struct {
+		name     string
+		message  Message
+		locale   Locale
+		expected string
+	}{
+		{name: "Translated", message: testMenuSettings, locale: LocaleDeDE, expected: "Einstellungen"},
+		{name: "Default text", message: testMenuSettings, locale: LocaleFrFR, expected: "Settings"},
+		{name: "Nested message argument", message: testRuleRequired.WithArgs(testFieldEmail), locale: LocaleDeDE, expected: "E-Mail ist erforderlich"},
+		{name: "Default with args", message: testRuleRequired.WithArgs(testFieldEmail), locale: LocaleFrFR, expected: "email is required"},
+		{name: "No default text", message: Message{Key: "unknown"}, locale: LocaleDeDE, expected: "unknown"},
+		{name: "Template fails", message: NewMessage("greeting", "Hello, %v!").WithArgs("Ann"), locale: LocaleDeDE, expected: "Hello, Ann!"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := tc.message.Localize(NewSingleMapTranslator(tc.locale, translator)); result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestMessage_WithArgs_DoesNotModifyOriginal(t *testing.T) {
+	withArgs := testRuleRequired.WithArgs("name")
+	if len(testRuleRequired.Args) != 0 {
+		t.Error("Expected original message to stay without args")
+	}
+	if withArgs.Description != testRuleRequired.Description || withArgs.Key != testRuleRequired.Key {
+		t.Error("Expected key & description to be copied")
+	}
+}
+
+func TestMessage_Format(t *testing.T) {
+	message := testRuleRequired.WithArgs("Name")
+	if s := fmt.Sprintf("%v|%s|%q", message, message, message); s != `Name is required|Name is required|"Name is required"` {
+		t.Errorf("Unexpected formatted message: %s", s)
+	}
+	if s := fmt.Sprint(testMenuSettings); s != "Settings" {
+		t.Errorf("Expected default text, got %q", s)
+	}
+}
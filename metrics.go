@@ -0,0 +1,211 @@
+package i18n
+
+import (
+	"expvar"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TranslationMetrics counts lookups, misses, fallbacks to other locales and render latency per key & locale.
+// It's an http.Handler serving the metrics in OpenMetrics text format. It is safe for concurrent use.
+type TranslationMetrics struct {
+	stats sync.Map // messageCacheKey => *keyStats
+}
+
+var _ http.Handler = (*TranslationMetrics)(nil)
+
+type keyStats struct {
+	lookups   atomic.Int64
+	misses    atomic.Int64
+	fallbacks atomic.Int64
+	latency   atomic.Int64 // nanoseconds
+}
+
+// KeyMetrics are metrics of a key in a requested locale
+type KeyMetrics struct {
+	Key       string        `json:"key"`
+	Locale    string        `json:"locale"`
+	Lookups   int64         `json:"lookups"`
+	Misses    int64         `json:"misses"`
+	Fallbacks int64         `json:"fallbacks"`
+	Latency   time.Duration `json:"latency"` // total render time
+}
+
+// LocaleMetrics are metrics of all keys in a requested locale
+type LocaleMetrics struct {
+	Lookups   int64   `json:"lookups"`
+	Misses    int64   `json:"misses"`
+	Fallbacks int64   `json:"fallbacks"`
+	MissRate  float64 `json:"missRate"`
+}
+
+// NewTranslationMetrics creates TranslationMetrics
+func NewTranslationMetrics() *TranslationMetrics {
+	return new(TranslationMetrics)
+}
+
+func (m *TranslationMetrics) record(result LookupResult, latency time.Duration) {
+	k := messageCacheKey{key: result.Key, locale: result.Locale}
+	v, ok := m.stats.Load(k)
+	if !ok {
+		v, _ = m.stats.LoadOrStore(k, new(keyStats))
+	}
+	stats := v.(*keyStats)
+	stats.lookups.Add(1)
+	switch {
+	case !result.Found:
+		stats.misses.Add(1)
+	case result.Fallback():
+		stats.fallbacks.Add(1)
+	}
+	stats.latency.Add(int64(latency))
+}
+
+// Keys returns metrics of looked up keys ordered by key & locale
+func (m *TranslationMetrics) Keys() []KeyMetrics {
+	var keys []KeyMetrics
+	m.stats.Range(func(k, v any) bool {
+		stats := v.(*keyStats)
+		keys = append(keys, KeyMetrics{
+			Key:       k.(messageCacheKey).key,
+			Locale:    k.(messageCacheKey).locale,
+			Lookups:   stats.lookups.Load(),
+			Misses:    stats.misses.Load(),
+			Fallbacks: stats.fallbacks.Load(),
+			Latency:   time.Duration(stats.latency.Load()),
+		})
+		return true
+	})
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Key != keys[j].Key {
+			return keys[i].Key < keys[j].Key
+		}
+		return keys[i].Locale < keys[j].Locale
+	})
+	return keys
+}
+
+// Locales returns metrics aggregated by requested locale
+func (m *TranslationMetrics) Locales() map[string]LocaleMetrics {
+	locales := make(map[string]LocaleMetrics)
+	for _, k := range m.Keys() {
+		locale := locales[k.Locale]
+		locale.Lookups += k.Lookups
+		locale.Misses += k.Misses
+		locale.Fallbacks += k.Fallbacks
+		locale.MissRate = float64(locale.Misses) / float64(locale.Lookups)
+		locales[k.Locale] = locale
+	}
+	return locales
+}
+
+// UnusedKeys returns keys that have not been looked up in any locale, e.g. keys of loaded translations
+func (m *TranslationMetrics) UnusedKeys(keys []string) (unused []string) {
+	used := make(map[string]bool)
+	m.stats.Range(func(k, _ any) bool {
+		used[k.(messageCacheKey).key] = true
+		return true
+	})
+	for _, key := range keys {
+		if !used[key] {
+			unused = append(unused, key)
+		}
+	}
+	return unused
+}
+
+// Publish exports metrics with expvar under the name as {"locales": {...}, "keys": [...]}.
+// Like expvar.Publish it panics if the name is already registered.
+func (m *TranslationMetrics) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return map[string]any{"locales": m.Locales(), "keys": m.Keys()}
+	}))
+}
+
+// ServeHTTP writes metrics in OpenMetrics text format
+func (m *TranslationMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+	keys := m.Keys()
+	counters := []struct {
+		name  string
+		help  string
+		value func(k KeyMetrics) int64
+	}{
+		{name: "i18n_lookups", help: "Translation lookups.", value: func(k KeyMetrics) int64 { return k.Lookups }},
+		{name: "i18n_misses", help: "Lookups of keys without translation.", value: func(k KeyMetrics) int64 { return k.Misses }},
+		{name: "i18n_fallbacks", help: "Lookups served from another locale.", value: func(k KeyMetrics) int64 { return k.Fallbacks }},
+	}
+	var b strings.Builder
+	for _, counter := range counters {
+		fmt.Fprintf(&b, "# TYPE %v counter\n# HELP %v %v\n", counter.name, counter.name, counter.help)
+		for _, k := range keys {
+			fmt.Fprintf(&b, "%v_total{%v} %d\n", counter.name, metricLabels(k), counter.value(k))
+		}
+	}
+	b.WriteString("# TYPE i18n_render_seconds summary\n# HELP i18n_render_seconds Time spent rendering translations.\n")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "i18n_render_seconds_sum{%v} %v\n", metricLabels(k), k.Latency.Seconds())
+		fmt.Fprintf(&b, "i18n_render_seconds_count{%v} %d\n", metricLabels(k), k.Lookups)
+	}
+	b.WriteString("# EOF\n")
+	_, _ = w.Write([]byte(b.String()))
+}
+
+var metricLabelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func metricLabels(k KeyMetrics) string {
+	return `key="` + metricLabelReplacer.Replace(k.Key) + `",locale="` + metricLabelReplacer.Replace(k.Locale) + `"`
+}
+
+// NewInstrumentedTranslator creates translator recording lookups of the translator to the metrics
+func NewInstrumentedTranslator(translator Translator, metrics *TranslationMetrics) Translator {
+	return instrumentedTranslator{translator: translator, metrics: metrics}
+}
+
+type instrumentedTranslator struct {
+	translator Translator
+	metrics    *TranslationMetrics
+}
+
+var _ Translator = (*instrumentedTranslator)(nil)
+
+func (t instrumentedTranslator) Lookup(key, locale string) LookupResult {
+	return t.translator.Lookup(key, locale)
+}
+
+func (t instrumentedTranslator) Translate(key, locale string, args ...any) string {
+	result := t.translator.Lookup(key, locale)
+	started := time.Now()
+	s := t.translator.Translate(key, locale, args...)
+	t.metrics.record(result, time.Since(started))
+	return s
+}
+
+func (t instrumentedTranslator) TranslateNoWarning(key, locale string, args ...any) string {
+	result := t.translator.Lookup(key, locale)
+	started := time.Now()
+	s := t.translator.TranslateNoWarning(key, locale, args...)
+	t.metrics.record(result, time.Since(started))
+	return s
+}
+
+func (t instrumentedTranslator) TranslateWithMap(key, locale string, args map[string]string) string {
+	result := t.translator.Lookup(key, locale)
+	started := time.Now()
+	s := t.translator.TranslateWithMap(key, locale, args)
+	t.metrics.record(result, time.Since(started))
+	return s
+}
+
+func (t instrumentedTranslator) TranslateE(key, locale string, args ...any) (string, error) {
+	result := t.translator.Lookup(key, locale)
+	started := time.Now()
+	s, err := t.translator.TranslateE(key, locale, args...)
+	t.metrics.record(result, time.Since(started))
+	return s, err
+}
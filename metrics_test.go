@@ -0,0 +1,116 @@
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInstrumentedTranslator(t *testing.T) {
+	// Prepare test data
+	metrics := NewTranslationMetrics()
+	translator := NewInstrumentedTranslator(NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"hello": {"en-US": "Hello, %v!", "de-DE": "Hallo, %v!"},
+		"bye":   {"en-US": "Bye"},
+	}), metrics)
+
+	if s := translator.Translate("hello", "de-DE", "Max"); s != "Hallo, Max!" {
+		t.Errorf("Unexpected translation: %q", s)
+	}
+	translator.TranslateNoWarning("hello", "de-DE", "Max")
+	translator.Translate("hello", "en-US", "Max")
+	translator.TranslateWithMap("bye", "de-DE", nil)
+	if _, err := translator.TranslateE("unknown", "de-DE"); !IsNotFound(err) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+
+	keys := metrics.Keys()
+	for i := range keys {
+		keys[i].Latency = 0
+	}
+	expected := []KeyMetrics{
+		{Key: "bye", Locale: "de-DE", Lookups: 1, Fallbacks: 1},
+		{Key: "hello", Locale: "de-DE", Lookups: 2},
+		{Key: "hello", Locale: "en-US", Lookups: 1},
+		{Key: "unknown", Locale: "de-DE", Lookups: 1, Misses: 1},
+	}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, keys)
+	}
+	if locale := metrics.Locales()["de-DE"]; locale.Lookups != 4 || locale.Misses != 1 || locale.Fallbacks != 1 || locale.MissRate != 0.25 {
+		t.Errorf("Unexpected locale metrics: %+v", locale)
+	}
+	if unused := metrics.UnusedKeys([]string{"hello", "bye", "title"}); !reflect.DeepEqual(unused, []string{"title"}) {
+		t.Errorf("Expected unused title, got %v", unused)
+	}
+}
+
+func TestInstrumentedTranslator_OtherTranslators(t *testing.T) {
+	metrics := NewTranslationMetrics()
+	translator := NewInstrumentedTranslator(keysTranslator{}, metrics)
+	_, _ = translator.TranslateE("hello", "en-US")
+	translator.Translate("hello", "en-US")
+	if keys := metrics.Keys(); len(keys) != 1 || keys[0].Lookups != 2 || keys[0].Misses != 2 {
+		t.Errorf("Expected misses of keys translator, got %+v", keys)
+	}
+}
+
+func TestTranslationMetrics_ServeHTTP(t *testing.T) {
+	// Prepare test data
+	metrics := NewTranslationMetrics()
+	translator := NewInstrumentedTranslator(NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"bye":   {"en-US": "Bye"},
+		"title": {"en-US": "Title"},
+	}), metrics)
+	translator.Translate("bye", "de-DE")
+	translator.Translate("title", `x"y`)
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/openmetrics-text") {
+		t.Errorf("Unexpected content type: %q", contentType)
+	}
+	body := recorder.Body.String()
+	for _, line := range []string{
+		"# TYPE i18n_lookups counter",
+		`i18n_lookups_total{key="bye",locale="de-DE"} 1`,
+		`i18n_fallbacks_total{key="bye",locale="de-DE"} 1`,
+		`i18n_misses_total{key="bye",locale="de-DE"} 0`,
+		`i18n_lookups_total{key="title",locale="x\"y"} 1`,
+		`i18n_render_seconds_count{key="bye",locale="de-DE"} 1`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected line %q in:\n%s", line, body)
+		}
+	}
+	if !strings.HasSuffix(body, "# EOF\n") {
+		t.Error("Expected exposition to end with # EOF")
+	}
+}
+
+func TestTranslationMetrics_Publish(t *testing.T) {
+	// Prepare test data
+	metrics := NewTranslationMetrics()
+	translator := NewInstrumentedTranslator(NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"hello": {"en-US": "Hello, %v!"},
+	}), metrics)
+
+	translator.Translate("hello", "en-US", "Max")
+	metrics.Publish("i18n_test_metrics")
+
+	var published struct {
+		Locales map[string]LocaleMetrics `json:"locales"`
+		Keys    []KeyMetrics             `json:"keys"`
+	}
+	if err := json.Unmarshal([]byte(expvar.Get("i18n_test_metrics").String()), &published); err != nil {
+		t.Fatal(err)
+	}
+	if published.Locales["en-US"].Lookups != 1 || len(published.Keys) != 1 {
+		t.Errorf("Unexpected published metrics: %+v", published)
+	}
+}
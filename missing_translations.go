@@ -0,0 +1,155 @@
+package i18n
+
+import (
+	"encoding/json"
+	"html/template"
+	"io"
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MissingTranslation describes a lookup of a key that has no translation for the requested locale
+type MissingTranslation struct {
+	Key          string
+	Locale       string // requested locale
+	ServedLocale string // locale of the text served instead, empty if the key was served as is
+	Text         string // text served instead
+}
+
+// MissingObserver is notified about missing translations, e.g. to collect them (see MissingCollector)
+type MissingObserver interface {
+	ObserveMissing(miss MissingTranslation)
+}
+
+// MissingObserverFunc is a function implementing MissingObserver
+type MissingObserverFunc func(miss MissingTranslation)
+
+func (f MissingObserverFunc) ObserveMissing(miss MissingTranslation) {
+	f(miss)
+}
+
+// WithMissingObserver sets observer notified about missing translations the translator warns about
+func WithMissingObserver(observer MissingObserver) MapTranslatorOption {
+	return func(t *mapTranslator) {
+		t.missingObserver = observer
+	}
+}
+
+// MissingEntry aggregates misses of a key for a requested locale
+type MissingEntry struct {
+	Key          string    `json:"key"`
+	Locale       string    `json:"locale"`
+	ServedLocale string    `json:"servedLocale,omitempty"`
+	Text         string    `json:"text"`
+	Count        int       `json:"count"`
+	FirstSeen    time.Time `json:"firstSeen"`
+	LastSeen     time.Time `json:"lastSeen"`
+	CallSite     string    `json:"callSite,omitempty"` // file:line of the first call outside of this package
+}
+
+// MissingCollector is a MissingObserver aggregating misses by key & requested locale.
+// It's an http.Handler serving a JSON report or an HTML one for "?format=html". It is safe for concurrent use.
+type MissingCollector struct {
+	mutex   sync.Mutex
+	entries map[messageCacheKey]*MissingEntry
+	now     func() time.Time
+}
+
+var _ MissingObserver = (*MissingCollector)(nil)
+var _ http.Handler = (*MissingCollector)(nil)
+
+// NewMissingCollector creates MissingCollector
+func NewMissingCollector() *MissingCollector {
+	return &MissingCollector{entries: make(map[messageCacheKey]*MissingEntry), now: time.Now}
+}
+
+func (c *MissingCollector) ObserveMissing(miss MissingTranslation) {
+	now := c.now()
+	k := messageCacheKey{key: miss.Key, locale: miss.Locale}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries[k]
+	if !ok {
+		entry = &MissingEntry{Key: miss.Key, Locale: miss.Locale, FirstSeen: now, CallSite: callSite()}
+		c.entries[k] = entry
+	}
+	entry.ServedLocale, entry.Text = miss.ServedLocale, miss.Text
+	entry.Count++
+	entry.LastSeen = now
+}
+
+// Entries returns aggregated misses ordered by key & locale
+func (c *MissingCollector) Entries() []MissingEntry {
+	c.mutex.Lock()
+	entries := make([]MissingEntry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		entries = append(entries, *entry)
+	}
+	c.mutex.Unlock()
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Key != entries[j].Key {
+			return entries[i].Key < entries[j].Key
+		}
+		return entries[i].Locale < entries[j].Locale
+	})
+	return entries
+}
+
+// Reset removes collected misses
+func (c *MissingCollector) Reset() {
+	c.mutex.Lock()
+	c.entries = make(map[messageCacheKey]*MissingEntry)
+	c.mutex.Unlock()
+}
+
+// WriteJSON writes misses of a locale within a namespace as a translation file (see LoadJSON)
+// with texts served instead as values for translators to replace
+func (c *MissingCollector) WriteJSON(w io.Writer, locale, namespace string) error {
+	translations := make(map[string]map[string]string)
+	for _, entry := range c.Entries() {
+		if entry.Locale == locale {
+			translations[entry.Key] = map[string]string{locale: entry.Text}
+		}
+	}
+	return WriteJSON(w, translations, locale, namespace)
+}
+
+var missingReportTemplate = template.Must(template.New("missing").Parse(`<!DOCTYPE html>
+<html><head><title>Missing translations</title></head><body>
+<table>
+<tr><th>Key</th><th>Locale</th><th>Served locale</th><th>Text</th><th>Count</th><th>First seen</th><th>Last seen</th><th>Call site</th></tr>
+{{range .}}<tr><td>{{.Key}}</td><td>{{.Locale}}</td><td>{{.ServedLocale}}</td><td>{{.Text}}</td><td>{{.Count}}</td><td>{{.FirstSeen.Format "2006-01-02 15:04:05"}}</td><td>{{.LastSeen.Format "2006-01-02 15:04:05"}}</td><td>{{.CallSite}}</td></tr>
+{{end}}</table>
+</body></html>
+`))
+
+func (c *MissingCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	entries := c.Entries()
+	if r.URL.Query().Get("format") == "html" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = missingReportTemplate.Execute(w, entries)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(entries)
+}
+
+var packagePrefix = reflect.TypeOf(MissingCollector{}).PkgPath() + "."
+
+// callSite returns file:line of the first caller outside of this package or of the outermost one
+func callSite() string {
+	pc := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pc[:runtime.Callers(2, pc)])
+	for {
+		frame, more := frames.Next()
+		if !more || !strings.HasPrefix(frame.Function, packagePrefix) || strings.HasSuffix(frame.File, "_test.go") {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+	}
+}
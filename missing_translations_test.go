@@ -0,0 +1,124 @@
+package i18n
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMissingCollector(t *testing.T) {
+	// Prepare test data
+	collector := NewMissingCollector()
+	now := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+	collector.now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+	translator := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"hello":                           {"en-US": "Hello", "de-DE": "Hallo"},
+		"bye":                             {"en-US": "Bye"},
+		NamespacedKey("billing", "title"): {"en-US": "Billing"},
+	}, WithMissingObserver(collector))
+
+	translator.Translate("hello", "de-DE")
+	translator.Translate("bye", "de-DE")
+	translator.Translate("bye", "de-DE")
+	translator.TranslateNoWarning("bye", "fr-FR")
+	translator.Translate("bye", "fr-FR")
+	_, _ = translator.TranslateE(NamespacedKey("billing", "title"), "de-DE")
+	translator.Translate("unknown", "de-DE")
+
+	entries := collector.Entries()
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %+v", entries)
+	}
+	bye := entries[1]
+	if bye.Key != "bye" || bye.Locale != "de-DE" || bye.ServedLocale != "en-US" || bye.Text != "Bye" || bye.Count != 2 {
+		t.Errorf("Unexpected entry: %+v", bye)
+	}
+	if !bye.FirstSeen.Before(bye.LastSeen) {
+		t.Errorf("Expected first seen before last seen, got %v & %v", bye.FirstSeen, bye.LastSeen)
+	}
+	if !strings.Contains(bye.CallSite, "missing_translations_test.go:") {
+		t.Errorf("Expected call site in the test, got %q", bye.CallSite)
+	}
+	if fr := entries[2]; fr.Key != "bye" || fr.Locale != "fr-FR" || fr.Count != 1 {
+		t.Errorf("Expected entries of a key ordered by locale, got %+v", fr)
+	}
+	if unknown := entries[3]; unknown.Key != "unknown" || unknown.ServedLocale != "" || unknown.Text != "unknown" {
+		t.Errorf("Unexpected entry: %+v", unknown)
+	}
+
+	var buffer bytes.Buffer
+	if err := collector.WriteJSON(&buffer, "de-DE", ""); err != nil {
+		t.Fatal(err)
+	}
+	translations := make(map[string]map[string]string)
+	if err := LoadJSON(translations, buffer.Bytes(), "de-DE", ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(translations) != 2 || translations["bye"]["de-DE"] != "Bye" || translations["unknown"]["de-DE"] != "unknown" {
+		t.Errorf("Unexpected dump: %s", buffer.String())
+	}
+
+	collector.Reset()
+	if entries = collector.Entries(); len(entries) != 0 {
+		t.Errorf("Expected no entries after reset, got %+v", entries)
+	}
+}
+
+func TestMissingObserverFunc(t *testing.T) {
+	// Prepare test data
+	var misses []MissingTranslation
+	translator := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"bye": {"en-US": "Bye"},
+	}, WithMissingObserver(MissingObserverFunc(func(miss MissingTranslation) {
+		misses = append(misses, miss)
+	})))
+
+	translator.Translate("bye", "de-DE")
+	if len(misses) != 1 || misses[0].Key != "bye" || misses[0].Locale != "de-DE" || misses[0].ServedLocale != "en-US" {
+		t.Errorf("Unexpected misses: %+v", misses)
+	}
+}
+
+func TestMissingCollector_ServeHTTP(t *testing.T) {
+	// Prepare test data
+	collector := NewMissingCollector()
+	collector.ObserveMissing(MissingTranslation{Key: "<b>bye</b>", Locale: "de-DE", ServedLocale: "en-US", Text: "Bye"})
+
+	testCases := []struct {
+		name        string
+		url         string
+		contentType string
+		contains    string
+	}{
+		{name: "JSON", url: "/missing", contentType: "application/json", contains: `"locale":"de-DE"`},
+		{name: "HTML", url: "/missing?format=html", contentType: "text/html; charset=utf-8", contains: "&lt;b&gt;bye&lt;/b&gt;"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			collector.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tc.url, nil))
+			if contentType := recorder.Header().Get("Content-Type"); contentType != tc.contentType {
+				t.Errorf("Expected content type %q, got %q", tc.contentType, contentType)
+			}
+			if body := recorder.Body.String(); !strings.Contains(body, tc.contains) {
+				t.Errorf("Expected body to contain %q, got %s", tc.contains, body)
+			}
+		})
+	}
+
+	recorder := httptest.NewRecorder()
+	collector.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	var entries []MissingEntry
+	if err := json.Unmarshal(recorder.Body.Bytes(), &entries); err != nil || len(entries) != 1 || entries[0].Count != 1 {
+		t.Errorf("Unexpected JSON report: %v, %s", err, recorder.Body.String())
+	}
+}
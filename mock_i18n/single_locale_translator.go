@@ -54,6 +54,20 @@ func (mr *MockSingleLocaleTranslatorMockRecorder) Locale() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Locale", reflect.TypeOf((*MockSingleLocaleTranslator)(nil).Locale))
 }
 
+// Lookup mocks base method.
+func (m *MockSingleLocaleTranslator) Lookup(key string) i18n.LookupResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", key)
+	ret0, _ := ret[0].(i18n.LookupResult)
+	return ret0
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockSingleLocaleTranslatorMockRecorder) Lookup(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockSingleLocaleTranslator)(nil).Lookup), key)
+}
+
 // Translate mocks base method.
 func (m *MockSingleLocaleTranslator) Translate(key string, args ...any) string {
 	m.ctrl.T.Helper()
@@ -73,6 +87,26 @@ func (mr *MockSingleLocaleTranslatorMockRecorder) Translate(key any, args ...any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Translate", reflect.TypeOf((*MockSingleLocaleTranslator)(nil).Translate), varargs...)
 }
 
+// TranslateE mocks base method.
+func (m *MockSingleLocaleTranslator) TranslateE(key string, args ...any) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{key}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TranslateE", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TranslateE indicates an expected call of TranslateE.
+func (mr *MockSingleLocaleTranslatorMockRecorder) TranslateE(key any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{key}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TranslateE", reflect.TypeOf((*MockSingleLocaleTranslator)(nil).TranslateE), varargs...)
+}
+
 // TranslateNoWarning mocks base method.
 func (m *MockSingleLocaleTranslator) TranslateNoWarning(key string, args ...any) string {
 	m.ctrl.T.Helper()
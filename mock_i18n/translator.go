@@ -12,6 +12,7 @@ package mock_i18n
 import (
 	reflect "reflect"
 
+	i18n "github.com/strongo/i18n"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -39,6 +40,20 @@ func (m *MockTranslator) EXPECT() *MockTranslatorMockRecorder {
 	return m.recorder
 }
 
+// Lookup mocks base method.
+func (m *MockTranslator) Lookup(key, locale string) i18n.LookupResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", key, locale)
+	ret0, _ := ret[0].(i18n.LookupResult)
+	return ret0
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockTranslatorMockRecorder) Lookup(key, locale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockTranslator)(nil).Lookup), key, locale)
+}
+
 // Translate mocks base method.
 func (m *MockTranslator) Translate(key, locale string, args ...any) string {
 	m.ctrl.T.Helper()
@@ -58,6 +73,26 @@ func (mr *MockTranslatorMockRecorder) Translate(key, locale any, args ...any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Translate", reflect.TypeOf((*MockTranslator)(nil).Translate), varargs...)
 }
 
+// TranslateE mocks base method.
+func (m *MockTranslator) TranslateE(key, locale string, args ...any) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{key, locale}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TranslateE", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TranslateE indicates an expected call of TranslateE.
+func (mr *MockTranslatorMockRecorder) TranslateE(key, locale any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{key, locale}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TranslateE", reflect.TypeOf((*MockTranslator)(nil).TranslateE), varargs...)
+}
+
 // TranslateNoWarning mocks base method.
 func (m *MockTranslator) TranslateNoWarning(key, locale string, args ...any) string {
 	m.ctrl.T.Helper()
@@ -0,0 +1,76 @@
+package i18n
+
+import "strings"
+
+// NamespaceSeparator separates namespace from a key, see NamespacedKey.
+// It's a control character (group separator) that can't appear in ordinary text, e.g. source texts used as keys.
+const NamespaceSeparator = "\x1d"
+
+// contextSeparator separates message context from a key as msgctxt does in gettext .mo files
+const contextSeparator = "\x04"
+
+// NamespacedKey returns key within a namespace (a domain), e.g. per bot or per package.
+// Namespace can be empty.
+func NamespacedKey(namespace, key string) string {
+	if namespace == "" {
+		return key
+	}
+	return namespace + NamespaceSeparator + key
+}
+
+// SplitNamespacedKey is reverse of NamespacedKey
+func SplitNamespacedKey(key string) (namespace, name string) {
+	if namespace, name, found := strings.Cut(key, NamespaceSeparator); found {
+		return namespace, name
+	}
+	return "", key
+}
+
+// ContextKey returns key with a disambiguation context (msgctxt), e.g. ContextKey("open", "verb").
+// Context can be empty. In translation files such keys are written as "verb\u0004open".
+func ContextKey(key, context string) string {
+	if context == "" {
+		return key
+	}
+	return context + contextSeparator + key
+}
+
+// SplitContextKey is reverse of ContextKey
+func SplitContextKey(key string) (name, context string) {
+	if context, name, found := strings.Cut(key, contextSeparator); found {
+		return name, context
+	}
+	return key, ""
+}
+
+// NewNamespaceTranslator creates translator that looks up keys in the namespace, see NamespacedKey
+func NewNamespaceTranslator(translator Translator, namespace string) Translator {
+	return namespaceTranslator{translator: translator, namespace: namespace}
+}
+
+type namespaceTranslator struct {
+	translator Translator
+	namespace  string
+}
+
+var _ Translator = (*namespaceTranslator)(nil)
+
+func (t namespaceTranslator) Translate(key, locale string, args ...any) string {
+	return t.translator.Translate(NamespacedKey(t.namespace, key), locale, args...)
+}
+
+func (t namespaceTranslator) TranslateNoWarning(key, locale string, args ...any) string {
+	return t.translator.TranslateNoWarning(NamespacedKey(t.namespace, key), locale, args...)
+}
+
+func (t namespaceTranslator) TranslateWithMap(key, locale string, args map[string]string) string {
+	return t.translator.TranslateWithMap(NamespacedKey(t.namespace, key), locale, args)
+}
+
+func (t namespaceTranslator) Lookup(key, locale string) LookupResult {
+	return t.translator.Lookup(NamespacedKey(t.namespace, key), locale)
+}
+
+func (t namespaceTranslator) TranslateE(key, locale string, args ...any) (string, error) {
+	return t.translator.TranslateE(NamespacedKey(t.namespace, key), locale, args...)
+}
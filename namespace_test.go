@@ -0,0 +1,89 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNamespacedKey(t *testing.T) {
+	testCases := []struct {
+		namespace string
+		key       string
+		expected  string
+	}{
+		{namespace: "", key: "title", expected: "title"},
+		{namespace: "billing", key: "title", expected: "billing\x1dtitle"},
+		{namespace: "bots/billing", key: "menu.title", expected: "bots/billing\x1dmenu.title"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			key := NamespacedKey(tc.namespace, tc.key)
+			if key != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, key)
+			}
+			if namespace, name := SplitNamespacedKey(key); namespace != tc.namespace || name != tc.key {
+				t.Errorf("Expected %q & %q, got %q & %q", tc.namespace, tc.key, namespace, name)
+			}
+		})
+	}
+}
+
+func TestContextKey(t *testing.T) {
+	if key := ContextKey("open", ""); key != "open" {
+		t.Errorf("Expected key without context as is, got %q", key)
+	}
+	key := ContextKey("open", "verb")
+	if key != "verb\x04open" {
+		t.Errorf("Unexpected key: %q", key)
+	}
+	if name, context := SplitContextKey(key); name != "open" || context != "verb" {
+		t.Errorf("Unexpected split: %q & %q", name, context)
+	}
+}
+
+func TestNamespaceTranslator(t *testing.T) {
+	// Prepare test data
+	base := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"title":                           {"en-US": "Title"},
+		NamespacedKey("billing", "title"): {"en-US": "Billing"},
+		NamespacedKey("billing", ContextKey("open", "verb")): {"en-US": "Open %v"},
+		NamespacedKey("billing", ContextKey("open", "adj")):  {"en-US": "Opened"},
+		NamespacedKey("billing", "greeting"):                 {"en-US": "Hello, {name}!"},
+	})
+	translator := NewNamespaceTranslator(base, "billing")
+
+	if s := translator.Translate("title", "en-US"); s != "Billing" {
+		t.Errorf("Expected namespaced translation, got %q", s)
+	}
+	if s := translator.TranslateNoWarning(ContextKey("open", "adj"), "en-US"); s != "Opened" {
+		t.Errorf("Expected translation with context, got %q", s)
+	}
+	if s, err := translator.TranslateE(ContextKey("open", "verb"), "en-US", "account"); err != nil || s != "Open account" {
+		t.Errorf("Unexpected result: %q, %v", s, err)
+	}
+	if s := translator.TranslateWithMap("greeting", "en-US", map[string]string{"name": "Jack"}); s != "Hello, Jack!" {
+		t.Errorf("Unexpected result: %q", s)
+	}
+	if _, err := translator.TranslateE("open", "en-US"); !IsNotFound(err) {
+		t.Errorf("Expected key without context to be not found, got %v", err)
+	}
+}
+
+func TestSplitNamespacedKey_sourceText(t *testing.T) {
+	testCases := []struct {
+		namespace string
+		key       string
+	}{
+		{namespace: "", key: "Error: %v"},
+		{namespace: "errors", key: "Error: %v"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.namespace, func(t *testing.T) {
+			if namespace, key := SplitNamespacedKey(NamespacedKey(tc.namespace, tc.key)); namespace != tc.namespace || key != tc.key {
+				t.Errorf("Expected %q & %q, got %q & %q", tc.namespace, tc.key, namespace, key)
+			}
+		})
+	}
+}
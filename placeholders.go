@@ -0,0 +1,471 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Placeholder syntax supported by FormatMessage:
+//
+//	{name}                          - named argument from a map or a struct field
+//	{0}                             - positional argument from a slice
+//	{amount, number}                - number with locale separators
+//	{amount, number, integer}       - other number styles: integer, percent, currency
+//	{when, date}, {when, date, iso} - date of a time.Time
+//	{when, time}, {when, time, medium}
+//	{user, select, female {...} other {...}} - text by value, for a Person by its gender
+//	{user, case, genitive}          - name inflected to a grammatical case, see Person & SetInflector
+//	{user, pronoun, object}         - pronoun of a Person
+//	{user.gender}                   - field of a struct or an item of a map argument
+//
+// Use "{{" and "}}" to output literal braces.
+
+var (
+	// ErrPlaceholderMissing is returned when a placeholder has no value in arguments
+	ErrPlaceholderMissing = errors.New("no value for placeholder")
+
+	// ErrPlaceholderUnused is returned for arguments not referenced by any placeholder
+	ErrPlaceholderUnused = errors.New("argument is not referenced by any placeholder")
+
+	// ErrPlaceholderFormat is returned when a value can not be formatted as requested by a placeholder
+	ErrPlaceholderFormat = errors.New("can not format placeholder value")
+
+	// ErrPlaceholderSyntax is returned for placeholders that can not be parsed
+	ErrPlaceholderSyntax = errors.New("malformed placeholder")
+)
+
+// PlaceholderError describes a problem with a specific placeholder or argument
+type PlaceholderError struct {
+	Placeholder string
+	Err         error
+}
+
+func (e *PlaceholderError) Error() string {
+	return fmt.Sprintf("placeholder {%s}: %v", e.Placeholder, e.Err)
+}
+
+func (e *PlaceholderError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes any placeholder error match ErrBadArgs
+func (e *PlaceholderError) Is(target error) bool {
+	return target == ErrBadArgs
+}
+
+type placeholder struct {
+	name   string // argument name, for positional arguments it's the index as a string
+	index  int    // -1 for named arguments
+	format string
+	style  string
+
+	options map[string][]messageSegment // of select placeholders
+}
+
+type messageSegment struct {
+	literal     string
+	placeholder *placeholder
+}
+
+func parsePlaceholders(s string) (segments []messageSegment, errs []error) {
+	var literal strings.Builder
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			segments = append(segments, messageSegment{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '{' && i+1 < len(s) && s[i+1] == '{':
+			literal.WriteByte('{')
+			i++
+		case c == '}' && i+1 < len(s) && s[i+1] == '}':
+			literal.WriteByte('}')
+			i++
+		case c == '{':
+			end := closingBrace(s[i:])
+			if end < 0 {
+				errs = append(errs, &PlaceholderError{Placeholder: s[i+1:], Err: ErrPlaceholderSyntax})
+				literal.WriteString(s[i:])
+				i = len(s)
+				break
+			}
+			p, err := parsePlaceholder(s[i+1 : i+end])
+			if err != nil {
+				errs = append(errs, err)
+				literal.WriteString(s[i : i+end+1])
+			} else {
+				flushLiteral()
+				segments = append(segments, messageSegment{placeholder: p})
+			}
+			i += end
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	flushLiteral()
+	return
+}
+
+// closingBrace returns index of the brace closing one at s[0] or -1, nested braces are skipped
+func closingBrace(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parsePlaceholder(s string) (*placeholder, error) {
+	parts := strings.SplitN(s, ",", 3)
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	if !isPlaceholderName(parts[0]) || len(parts) > 2 && parts[1] != "select" && strings.ContainsAny(parts[2], ",{}") {
+		return nil, &PlaceholderError{Placeholder: s, Err: ErrPlaceholderSyntax}
+	}
+	p := placeholder{name: parts[0], index: -1}
+	if index, err := strconv.Atoi(p.name); err == nil {
+		p.index = index
+	}
+	if len(parts) > 1 {
+		p.format = parts[1]
+	}
+	if len(parts) > 2 {
+		p.style = parts[2]
+	}
+	if p.format == "select" {
+		var err error
+		if p.options, err = parseSelectOptions(p.style); err != nil {
+			return nil, &PlaceholderError{Placeholder: s, Err: err}
+		}
+		p.style = ""
+	}
+	return &p, nil
+}
+
+// parseSelectOptions parses options of a select placeholder like "male {He} female {She} other {They}"
+func parseSelectOptions(s string) (map[string][]messageSegment, error) {
+	options := make(map[string][]messageSegment)
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimSpace(s) {
+		start := strings.IndexByte(s, '{')
+		if start <= 0 {
+			return nil, fmt.Errorf("%w: expected option name followed by {text}", ErrPlaceholderSyntax)
+		}
+		name := strings.TrimSpace(s[:start])
+		end := closingBrace(s[start:])
+		if !isPlaceholderName(name) || end < 0 {
+			return nil, fmt.Errorf("%w: malformed option %q", ErrPlaceholderSyntax, name)
+		}
+		segments, errs := parsePlaceholders(s[start+1 : start+end])
+		if len(errs) > 0 {
+			return nil, errors.Join(errs...)
+		}
+		options[name] = segments
+		s = s[start+end+1:]
+	}
+	if len(options) == 0 {
+		return nil, fmt.Errorf("%w: select has no options", ErrPlaceholderSyntax)
+	}
+	return options, nil
+}
+
+func isPlaceholderName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !(c == '_' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// placeholderValues resolves placeholder values from maps, slices, structs or a single value
+type placeholderValues struct {
+	v reflect.Value
+}
+
+func (pv placeholderValues) get(p *placeholder) (any, bool) {
+	if v, ok := pv.getByName(p); ok {
+		return v, true
+	}
+	if head, rest, found := strings.Cut(p.name, "."); found {
+		if v, ok := pv.getByName(&placeholder{name: head, index: -1}); ok {
+			return newPlaceholderValues(v).get(&placeholder{name: rest, index: -1})
+		}
+	}
+	return nil, false
+}
+
+func (pv placeholderValues) getByName(p *placeholder) (any, bool) {
+	v := pv.v
+	if !v.IsValid() {
+		return nil, false
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		if item := v.MapIndex(reflect.ValueOf(p.name).Convert(v.Type().Key())); item.IsValid() {
+			return item.Interface(), true
+		}
+		return nil, false
+	case reflect.Slice, reflect.Array:
+		if p.index >= 0 && p.index < v.Len() {
+			return v.Index(p.index).Interface(), true
+		}
+		return nil, false
+	case reflect.Struct:
+		return structFieldValue(v, p.name)
+	}
+	if p.index == 0 {
+		return v.Interface(), true
+	}
+	return nil, false
+}
+
+// unused returns names of map keys or slice indexes that are not in used
+func (pv placeholderValues) unused(used map[string]bool) (names []string) {
+	v := pv.v
+	if !v.IsValid() {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		for _, k := range v.MapKeys() {
+			if name := k.String(); !used[name] {
+				names = append(names, name)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if name := strconv.Itoa(i); !used[name] {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+func newPlaceholderValues(args any) placeholderValues {
+	v := reflect.ValueOf(args)
+	for v.IsValid() && v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return placeholderValues{}
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		// []byte is a single value rather than a list of arguments
+		return placeholderValues{v: reflect.ValueOf([]any{args})}
+	}
+	return placeholderValues{v: v}
+}
+
+func structFieldValue(v reflect.Value, name string) (any, bool) {
+	t := v.Type()
+	var byFold = -1
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if tag := field.Tag.Get("i18n"); tag == name || tag == "" && field.Name == name {
+			return v.Field(i).Interface(), true
+		}
+		if byFold < 0 && strings.EqualFold(field.Name, name) {
+			byFold = i
+		}
+	}
+	if byFold >= 0 {
+		return v.Field(byFold).Interface(), true
+	}
+	return nil, false
+}
+
+func formatPlaceholderValue(locale string, p *placeholder, v any) (string, error) {
+	switch p.format {
+	case "":
+		switch v := v.(type) {
+		case string:
+			return v, nil
+		case Money:
+			return formatCurrency(locale, v), nil
+		case time.Time:
+			return FormatDate(locale, v, "")
+		case fmt.Stringer:
+			return v.String(), nil
+		}
+		if _, _, isNumber := toFloat(v); isNumber {
+			return FormatNumber(locale, v, "")
+		}
+		return fmt.Sprint(v), nil
+	case "number":
+		return FormatNumber(locale, v, p.style)
+	case "date", "time":
+		t, ok := v.(time.Time)
+		if !ok {
+			return "", fmt.Errorf("%w: %T is not time.Time", ErrPlaceholderFormat, v)
+		}
+		if p.format == "date" {
+			return FormatDate(locale, t, p.style)
+		}
+		return FormatTime(locale, t, p.style)
+	case "case":
+		return inflect(locale, v, GrammaticalCase(p.style)), nil
+	case "pronoun":
+		switch person := v.(type) {
+		case Person:
+			return person.pronoun(p.style)
+		case *Person:
+			return person.pronoun(p.style)
+		}
+		return "", fmt.Errorf("%w: %T is not a Person", ErrPlaceholderFormat, v)
+	default:
+		return "", fmt.Errorf("%w: unknown format %q", ErrPlaceholderFormat, p.format)
+	}
+}
+
+func renderSegments(locale string, segments []messageSegment, args any) (string, error) {
+	r := segmentsRenderer{locale: locale, values: newPlaceholderValues(args), used: make(map[string]bool, len(segments))}
+	r.render(segments)
+	for _, name := range r.values.unused(r.used) {
+		r.errs = append(r.errs, &PlaceholderError{Placeholder: name, Err: ErrPlaceholderUnused})
+	}
+	return r.b.String(), errors.Join(r.errs...)
+}
+
+type segmentsRenderer struct {
+	locale string
+	values placeholderValues
+	used   map[string]bool
+	errs   []error
+	b      strings.Builder
+}
+
+func (r *segmentsRenderer) render(segments []messageSegment) {
+	for _, segment := range segments {
+		p := segment.placeholder
+		if p == nil {
+			r.b.WriteString(segment.literal)
+			continue
+		}
+		r.used[p.name] = true
+		if head, _, found := strings.Cut(p.name, "."); found {
+			r.used[head] = true
+		}
+		v, ok := r.values.get(p)
+		if !ok {
+			r.errs = append(r.errs, &PlaceholderError{Placeholder: p.name, Err: ErrPlaceholderMissing})
+			r.b.WriteString("{" + p.name + "}")
+			continue
+		}
+		if p.format == "select" || p.format == "case" || p.format == "pronoun" {
+			v = r.person(p, v)
+		}
+		if p.format == "select" {
+			option, ok := p.options[selectKey(v)]
+			if !ok {
+				option, ok = p.options["other"]
+			}
+			if !ok {
+				r.errs = append(r.errs, &PlaceholderError{Placeholder: p.name, Err: fmt.Errorf("%w: no option for %q", ErrPlaceholderFormat, selectKey(v))})
+			}
+			r.render(option)
+			continue
+		}
+		s, err := formatPlaceholderValue(r.locale, p, v)
+		if err != nil {
+			r.errs = append(r.errs, &PlaceholderError{Placeholder: p.name, Err: err})
+			s = fmt.Sprint(v)
+		}
+		r.b.WriteString(s)
+	}
+}
+
+// person restores a Person from flat arguments made by Person.MapArgs, e.g. passed to TranslateWithMap,
+// so select, case & pronoun placeholders get the gender, pronouns & the inflected form of a name
+func (r *segmentsRenderer) person(p *placeholder, v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	name := p.name
+	var found bool
+	field := func(path string) string {
+		value, ok := r.values.getByName(&placeholder{name: name + "." + path, index: -1})
+		if !ok {
+			return ""
+		}
+		found = true
+		r.used[name+"."+path] = true
+		return fmt.Sprint(value)
+	}
+	person := Person{
+		Name:   s,
+		Gender: Gender(field("gender")),
+		Pronouns: Pronouns{
+			Subject:    field("pronouns.subject"),
+			Object:     field("pronouns.object"),
+			Possessive: field("pronouns.possessive"),
+		},
+	}
+	if p.format == "case" {
+		if form := field("cases." + p.style); form != "" {
+			person.Cases = map[GrammaticalCase]string{GrammaticalCase(p.style): form}
+		}
+	}
+	if !found {
+		return v
+	}
+	return person
+}
+
+// FormatMessage replaces placeholders like {name}, {0} or {amount, number, currency} with values
+// formatted for the locale. Args can be a map with string keys, a slice, a struct or a single value.
+// The returned error joins a *PlaceholderError for each missing, unused or malformed placeholder,
+// while the returned string is still usable with unresolved placeholders left as is.
+func FormatMessage(locale, message string, args any) (string, error) {
+	segments, parseErrs := parsePlaceholders(message)
+	s, err := renderSegments(locale, segments, args)
+	if len(parseErrs) > 0 {
+		err = errors.Join(append(parseErrs, err)...)
+	}
+	return s, err
+}
+
+// valuesFormatter is implemented by translators that cache compiled messages
+type valuesFormatter interface {
+	formatValues(key, locale string, args any) (string, error)
+}
+
+// TranslateWithValues translates a message with named or positional placeholders, see FormatMessage
+func TranslateWithValues(t Translator, key, locale string, args any) (string, error) {
+	if f, ok := t.(valuesFormatter); ok {
+		return f.formatValues(key, locale, args)
+	}
+	s, err := t.TranslateE(key, locale)
+	if err != nil {
+		return s, err
+	}
+	return FormatMessage(locale, s, args)
+}
@@ -0,0 +1,318 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFormatMessage(t *testing.T) {
+	when := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		locale   string
+		message  string
+		args     any
+		expected string
+		errIs    []error
+	}{
+		{
+			name:     "Named from map[string]any",
+			locale:   "en-US",
+			message:  "Hello, {name}!",
+			args:     map[string]any{"name": "World"},
+			expected: "Hello, World!",
+		},
+		{
+			name:     "Named from map[string]string",
+			locale:   "en-US",
+			message:  "{name} is {age}",
+			args:     map[string]string{"name": "John", "age": "30"},
+			expected: "John is 30",
+		},
+		{
+			name:     "Positional reordered by translator",
+			locale:   "de-DE",
+			message:  "{1} von {0}",
+			args:     []any{"Alice", "Bob"},
+			expected: "Bob von Alice",
+		},
+		{
+			name:     "Single value",
+			locale:   "en-US",
+			message:  "Count: {0}",
+			args:     1234,
+			expected: "Count: 1,234",
+		},
+		{
+			name:    "Struct fields by name, tag and case-insensitive",
+			locale:  "en-US",
+			message: "{Name}/{name}/{nick}",
+			args: struct {
+				Name     string
+				Nickname string `i18n:"nick"`
+			}{Name: "John", Nickname: "Johnny"},
+			expected: "John/John/Johnny",
+		},
+		{
+			name:    "Pointer to struct",
+			locale:  "en-US",
+			message: "Hi {name}",
+			args: &struct {
+				Name string
+			}{Name: "Ann"},
+			expected: "Hi Ann",
+		},
+		{
+			name:     "Number in German",
+			locale:   "de-DE",
+			message:  "{n, number}",
+			args:     map[string]any{"n": 1234567.891},
+			expected: "1.234.567,891",
+		},
+		{
+			name:     "Percent in French",
+			locale:   "fr-FR",
+			message:  "{p, number, percent}",
+			args:     map[string]any{"p": 0.25},
+			expected: "25 %",
+		},
+		{
+			name:     "Currency of locale region",
+			locale:   "en-US",
+			message:  "{amount, number, currency}",
+			args:     map[string]any{"amount": 1234.5},
+			expected: "$1,234.50",
+		},
+		{
+			name:     "Currency after amount",
+			locale:   "ru-RU",
+			message:  "{amount, number, currency}",
+			args:     map[string]any{"amount": Money{Amount: 99.9, Currency: "EUR"}},
+			expected: "99,90 €",
+		},
+		{
+			name:     "Date and time",
+			locale:   "en-US",
+			message:  "{when, date} {when, time}",
+			args:     map[string]any{"when": when},
+			expected: "3/5/2024 2:30 PM",
+		},
+		{
+			name:     "Date in Ukrainian",
+			locale:   "uk-UA",
+			message:  "{when, date} {when, time}",
+			args:     map[string]any{"when": when},
+			expected: "05.03.2024 14:30",
+		},
+		{
+			name:     "Escaped braces",
+			locale:   "en-US",
+			message:  "{{literal}} {x}",
+			args:     map[string]any{"x": 1},
+			expected: "{literal} 1",
+		},
+		{
+			name:     "Missing placeholder",
+			locale:   "en-US",
+			message:  "Hello, {name}!",
+			args:     map[string]any{},
+			expected: "Hello, {name}!",
+			errIs:    []error{ErrPlaceholderMissing},
+		},
+		{
+			name:     "Unused argument",
+			locale:   "en-US",
+			message:  "Hello!",
+			args:     map[string]any{"name": "World"},
+			expected: "Hello!",
+			errIs:    []error{ErrPlaceholderUnused},
+		},
+		{
+			name:     "Unknown format",
+			locale:   "en-US",
+			message:  "{x, color}",
+			args:     map[string]any{"x": "red"},
+			expected: "red",
+			errIs:    []error{ErrPlaceholderFormat},
+		},
+		{
+			name:     "Date by default",
+			locale:   "en-US",
+			message:  "{when}",
+			args:     map[string]any{"when": when},
+			expected: "3/5/2024",
+		},
+		{
+			name:     "Money by default",
+			locale:   "en-US",
+			message:  "{price}",
+			args:     map[string]any{"price": Money{Amount: 5, Currency: "EUR"}},
+			expected: "€5.00",
+		},
+		{
+			name:     "Bytes are a single value",
+			locale:   "en-US",
+			message:  "{0}",
+			args:     []byte("abc"),
+			expected: "[97 98 99]",
+		},
+		{
+			name:    "Unexported struct field",
+			locale:  "en-US",
+			message: "{Name}",
+			args: struct {
+				id   int
+				Name string
+			}{id: 1, Name: "Ann"},
+			expected: "Ann",
+		},
+		{
+			name:     "Not a time",
+			locale:   "en-US",
+			message:  "{when, date}",
+			args:     map[string]any{"when": "tomorrow"},
+			expected: "tomorrow",
+			errIs:    []error{ErrPlaceholderFormat},
+		},
+		{
+			name:     "No arguments",
+			locale:   "en-US",
+			message:  "Hello, {name}!",
+			args:     nil,
+			expected: "Hello, {name}!",
+			errIs:    []error{ErrPlaceholderMissing},
+		},
+		{
+			name:     "Nil pointer",
+			locale:   "en-US",
+			message:  "Hello, {name}!",
+			args:     (*struct{ Name string })(nil),
+			expected: "Hello, {name}!",
+			errIs:    []error{ErrPlaceholderMissing},
+		},
+		{
+			name:     "Map without string keys",
+			locale:   "en-US",
+			message:  "{1}",
+			args:     map[int]string{1: "one"},
+			expected: "{1}",
+			errIs:    []error{ErrPlaceholderMissing},
+		},
+		{
+			name:     "Index out of range",
+			locale:   "en-US",
+			message:  "{0} {1}",
+			args:     []any{"a"},
+			expected: "a {1}",
+			errIs:    []error{ErrPlaceholderMissing},
+		},
+		{
+			name:     "Name of a single value",
+			locale:   "en-US",
+			message:  "Count: {count}",
+			args:     5,
+			expected: "Count: {count}",
+			errIs:    []error{ErrPlaceholderMissing},
+		},
+		{
+			name:     "Unused positional argument",
+			locale:   "en-US",
+			message:  "{0}",
+			args:     []any{"a", "b"},
+			expected: "a",
+			errIs:    []error{ErrPlaceholderUnused},
+		},
+		{
+			name:     "Empty placeholder",
+			locale:   "en-US",
+			message:  "Hello, {}",
+			args:     nil,
+			expected: "Hello, {}",
+			errIs:    []error{ErrPlaceholderSyntax},
+		},
+		{
+			name:     "Invalid placeholder name",
+			locale:   "en-US",
+			message:  "Hello, {first name}",
+			args:     nil,
+			expected: "Hello, {first name}",
+			errIs:    []error{ErrPlaceholderSyntax},
+		},
+		{
+			name:     "Unclosed placeholder",
+			locale:   "en-US",
+			message:  "Hello, {name",
+			args:     nil,
+			expected: "Hello, {name",
+			errIs:    []error{ErrPlaceholderSyntax},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := FormatMessage(tc.locale, tc.message, tc.args)
+			if result != tc.expected {
+				t.Errorf("Expected FormatMessage(%q, %q, %v) to return %q, got %q",
+					tc.locale, tc.message, tc.args, tc.expected, result)
+			}
+			if len(tc.errIs) == 0 && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			for _, target := range tc.errIs {
+				if !errors.Is(err, target) {
+					t.Errorf("Expected error to match %v, got %v", target, err)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatMessage_PlaceholderError(t *testing.T) {
+	_, err := FormatMessage("en-US", "{a} {b}", map[string]any{"a": 1})
+	var placeholderErr *PlaceholderError
+	if !errors.As(err, &placeholderErr) {
+		t.Fatalf("Expected *PlaceholderError, got %v", err)
+	}
+	if placeholderErr.Placeholder != "b" {
+		t.Errorf("Expected placeholder %q, got %q", "b", placeholderErr.Placeholder)
+	}
+}
+
+func TestTranslateWithValues(t *testing.T) {
+	translator := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"balance": {
+			"en-US": "{name}, your balance is {amount, number, currency}",
+			"de-DE": "{name}, Ihr Kontostand beträgt {amount, number, currency}",
+		},
+	})
+	args := map[string]any{"name": "Max", "amount": 1500}
+
+	result, err := TranslateWithValues(translator, "balance", "de-DE", args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := "Max, Ihr Kontostand beträgt 1.500,00 €"; result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestTranslateWithValues_otherTranslators(t *testing.T) {
+	// Prepare test data
+	ctx := context.Background()
+	translator := NewLayeredTranslator(ctx, []Layer{
+		{Name: "defaults", Translator: NewMapTranslator(ctx, "en-US", map[string]map[string]string{
+			"hello": {"en-US": "Hello, {name}!"},
+		})},
+	})
+
+	result, err := TranslateWithValues(translator, "hello", "en-US", map[string]any{"name": "Max"})
+	if err != nil || result != "Hello, Max!" {
+		t.Errorf("Expected %q, got %q, %v", "Hello, Max!", result, err)
+	}
+	if result, err = TranslateWithValues(keysTranslator{}, "hello", "en-US", map[string]any{"name": "Max"}); !IsNotFound(err) || result != "" {
+		t.Errorf("Expected not found error, got %q, %v", result, err)
+	}
+}
@@ -0,0 +1,124 @@
+package i18n
+
+import (
+	"math"
+)
+
+// PluralCategory is a CLDR plural category
+type PluralCategory string
+
+const (
+	PluralZero  PluralCategory = "zero"
+	PluralOne   PluralCategory = "one"
+	PluralTwo   PluralCategory = "two"
+	PluralFew   PluralCategory = "few"
+	PluralMany  PluralCategory = "many"
+	PluralOther PluralCategory = "other"
+)
+
+// PluralKey returns key of a plural form, e.g. "files_few" for key "files" and category "few"
+func PluralKey(key string, category PluralCategory) string {
+	return key + "_" + string(category)
+}
+
+// GetPluralCategory returns plural category of a number for the locale (cardinal rules of CLDR).
+// Non-numeric values are treated as PluralOther.
+func GetPluralCategory(locale string, n any) PluralCategory {
+	f, _, ok := toFloat(n)
+	if !ok {
+		return PluralOther
+	}
+	f = math.Abs(f)
+	isInt := f == math.Trunc(f)
+	i := int64(f)
+	switch localeLang(locale) {
+	case "ja", "ko", "zh", "id":
+		return PluralOther
+	case "fr", "fa":
+		if i == 0 || i == 1 {
+			return PluralOne
+		}
+	case "pt":
+		if baseLocale(locale) == LocaleCodePtBR && (i == 0 || i == 1) || isInt && i == 1 {
+			return PluralOne
+		}
+	case "ru", "uk":
+		if !isInt {
+			return PluralOther
+		}
+		return slavicPluralCategory(i, i%10 == 1 && i%100 != 11)
+	case "pl":
+		if !isInt {
+			return PluralOther
+		}
+		return slavicPluralCategory(i, i == 1)
+	case "ar":
+		if !isInt {
+			return PluralOther
+		}
+		switch i100 := i % 100; {
+		case i == 0:
+			return PluralZero
+		case i == 1:
+			return PluralOne
+		case i == 2:
+			return PluralTwo
+		case i100 >= 3 && i100 <= 10:
+			return PluralFew
+		case i100 >= 11:
+			return PluralMany
+		}
+	default:
+		if isInt && i == 1 {
+			return PluralOne
+		}
+	}
+	return PluralOther
+}
+
+func slavicPluralCategory(i int64, isOne bool) PluralCategory {
+	i10, i100 := i%10, i%100
+	switch {
+	case isOne:
+		return PluralOne
+	case i10 >= 2 && i10 <= 4 && (i100 < 12 || i100 > 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// TranslatePlural translates a plural form of a message for the count n.
+// Plural forms are stored under keys built by PluralKey() and "other" form is used if the specific one is missing.
+// If no args provided n is passed as the only argument.
+func TranslatePlural(t Translator, key, locale string, n any, args ...any) string {
+	return translatePlural(locale, key, n, args,
+		func(key string, args ...any) (string, error) {
+			return t.TranslateE(key, locale, args...)
+		},
+		func(key string, args ...any) string {
+			return t.Translate(key, locale, args...)
+		},
+	)
+}
+
+// TranslatePluralSingle is TranslatePlural for a SingleLocaleTranslator
+func TranslatePluralSingle(t SingleLocaleTranslator, key string, n any, args ...any) string {
+	return translatePlural(t.Locale().Code5, key, n, args, t.TranslateE, t.Translate)
+}
+
+func translatePlural(
+	locale, key string, n any, args []any,
+	translateE func(key string, args ...any) (string, error),
+	translate func(key string, args ...any) string,
+) string {
+	if len(args) == 0 {
+		args = []any{n}
+	}
+	for _, category := range []PluralCategory{GetPluralCategory(locale, n), PluralOther} {
+		if s, err := translateE(PluralKey(key, category), args...); !IsNotFound(err) {
+			return s
+		}
+	}
+	return translate(key, args...)
+}
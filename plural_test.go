@@ -0,0 +1,106 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetPluralCategory(t *testing.T) {
+	testCases := []struct {
+		locale   string
+		n        any
+		expected PluralCategory
+	}{
+		{"en-US", 1, PluralOne},
+		{"en-US", 0, PluralOther},
+		{"en-US", 2, PluralOther},
+		{"en-US", 1.5, PluralOther},
+		{"de-DE", 1, PluralOne},
+		{"fr-FR", 0, PluralOne},
+		{"fr-FR", 1.5, PluralOne},
+		{"fr-FR", 2, PluralOther},
+		{"pt-BR", 0, PluralOne},
+		{"pt-PT", 0, PluralOther},
+		{"ja-JP", 1, PluralOther},
+		{"ru-RU", 1, PluralOne},
+		{"ru-RU", 21, PluralOne},
+		{"ru-RU", 11, PluralMany},
+		{"ru-RU", 3, PluralFew},
+		{"ru-RU", 13, PluralMany},
+		{"ru-RU", 24, PluralFew},
+		{"ru-RU", 5, PluralMany},
+		{"uk-UA", 1.5, PluralOther},
+		{"pl-PL", 1, PluralOne},
+		{"pl-PL", 21, PluralMany},
+		{"pl-PL", 22, PluralFew},
+		{"pl-PL", 1.5, PluralOther},
+		{"ar-EG", 0, PluralZero},
+		{"ar-EG", 1, PluralOne},
+		{"ar-EG", 2, PluralTwo},
+		{"ar-EG", 5, PluralFew},
+		{"ar-EG", 11, PluralMany},
+		{"ar-EG", 100, PluralOther},
+		{"ar-EG", 1.5, PluralOther},
+		{"en-US", "abc", PluralOther},
+	}
+
+	for _, tc := range testCases {
+		if result := GetPluralCategory(tc.locale, tc.n); result != tc.expected {
+			t.Errorf("Expected GetPluralCategory(%q, %v) to return %q, got %q", tc.locale, tc.n, tc.expected, result)
+		}
+	}
+}
+
+func TestTranslatePlural(t *testing.T) {
+	translator := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"files_one": {
+			"en-US": "%d file",
+			"ru-RU": "%d файл",
+		},
+		"files_few": {
+			"ru-RU": "%d файла",
+		},
+		"files_other": {
+			"en-US": "%d files",
+			"ru-RU": "%d файлов",
+		},
+		"apples": {
+			"en-US": "%d apples",
+		},
+	})
+
+	testCases := []struct {
+		locale   string
+		key      string
+		n        int
+		expected string
+	}{
+		{"en-US", "files", 1, "1 file"},
+		{"en-US", "files", 5, "5 files"},
+		{"ru-RU", "files", 1, "1 файл"},
+		{"ru-RU", "files", 3, "3 файла"},
+		{"ru-RU", "files", 5, "5 файлов"},
+		{"en-US", "apples", 2, "2 apples"},
+	}
+
+	for _, tc := range testCases {
+		if result := TranslatePlural(translator, tc.key, tc.locale, tc.n); result != tc.expected {
+			t.Errorf("Expected TranslatePlural(%q, %q, %v) to return %q, got %q", tc.key, tc.locale, tc.n, tc.expected, result)
+		}
+	}
+
+	if result := TranslatePlural(translator, "files", "en-US", 2, "two"); result != "%!d(string=two) files" {
+		t.Errorf("Expected explicit args to be used, got %q", result)
+	}
+}
+
+func TestTranslatePluralSingle(t *testing.T) {
+	translator := NewSingleMapTranslator(LocaleUkUA, NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"days_one":   {"uk-UA": "%d день"},
+		"days_few":   {"uk-UA": "%d дні"},
+		"days_other": {"uk-UA": "%d днів"},
+	}))
+	if result := TranslatePluralSingle(translator, "days", 22); result != "22 дні" {
+		t.Errorf("Expected %q, got %q", "22 дні", result)
+	}
+}
@@ -0,0 +1,244 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ReloadEvent describes a reload of translation files
+type ReloadEvent struct {
+	Time    time.Time
+	Changed []string // paths of added, modified and removed files
+	Err     error    // if not nil the previous translations are kept
+}
+
+// ReloadOption configures ReloadingTranslator
+type ReloadOption func(t *ReloadingTranslator)
+
+// WithReloadInterval sets how often Run polls files for changes, default is 2 seconds. Panics if interval is not positive.
+func WithReloadInterval(interval time.Duration) ReloadOption {
+	interval = positiveInterval("WithReloadInterval", interval)
+	return func(t *ReloadingTranslator) {
+		t.interval = interval
+	}
+}
+
+// WithReloadValidator adds validation of loaded translations in addition to ValidateTranslations
+func WithReloadValidator(validate func(translations map[string]map[string]string) error) ReloadOption {
+	return func(t *ReloadingTranslator) {
+		t.validators = append(t.validators, validate)
+	}
+}
+
+// WithReloadHandler sets function called after each reload with changed files
+func WithReloadHandler(handler func(event ReloadEvent)) ReloadOption {
+	return func(t *ReloadingTranslator) {
+		t.handler = handler
+	}
+}
+
+// WithReloadTranslatorOptions sets options of translators created for loaded translations,
+// a logger set by WithLogger also logs failed reloads
+func WithReloadTranslatorOptions(options ...MapTranslatorOption) ReloadOption {
+	return func(t *ReloadingTranslator) {
+		t.translatorOptions = options
+	}
+}
+
+// ReloadingTranslator serves translation files of a file system (see LoadFS) and reloads them when they change.
+// Reloads are validated and swapped atomically so readers are never blocked,
+// and if a reload fails the previous translations are kept. It is safe for concurrent use.
+type ReloadingTranslator struct {
+	swappableTranslator
+	fsys       fs.FS
+	validators []func(translations map[string]map[string]string) error
+	handler    func(event ReloadEvent)
+
+	reloadMutex sync.Mutex // serializes reloads
+	files       map[string]loadedFile
+}
+
+type loadedFile struct {
+	modTime      time.Time
+	size         int64
+	translations map[string]map[string]string
+	err          error
+}
+
+var _ Translator = (*ReloadingTranslator)(nil)
+
+// NewReloadingTranslator loads translation files of the file system, e.g. os.DirFS(dir), and returns
+// translator that reloads them on Reload or while Run is polling
+func NewReloadingTranslator(c context.Context, fsys fs.FS, defaultLocale string, options ...ReloadOption) (*ReloadingTranslator, error) {
+	t := &ReloadingTranslator{
+		swappableTranslator: swappableTranslator{c: c, defaultLocale: defaultLocale, interval: 2 * time.Second},
+		fsys:                fsys,
+	}
+	for _, option := range options {
+		option(t)
+	}
+	if _, err := t.reload(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Run polls files for changes until the context is done
+func (t *ReloadingTranslator) Run(ctx context.Context) {
+	t.run(ctx, func() {
+		_ = t.Reload()
+	})
+}
+
+// Reload reloads changed files, it does nothing if there are no changes
+func (t *ReloadingTranslator) Reload() error {
+	changed, err := t.reload()
+	if len(changed) == 0 && err == nil {
+		return nil
+	}
+	if err != nil {
+		logAttrs(t.c, t.logger(), slog.LevelError, "Failed to reload translations", slog.Any("changed", changed), slog.Any("error", err))
+	}
+	if t.handler != nil {
+		t.handler(ReloadEvent{Time: time.Now(), Changed: changed, Err: err})
+	}
+	return err
+}
+
+func (t *ReloadingTranslator) reload() (changed []string, err error) {
+	t.reloadMutex.Lock()
+	defer t.reloadMutex.Unlock()
+	files := make(map[string]loadedFile, len(t.files))
+	err = fs.WalkDir(t.fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		file, ok := ParseTranslationFilePath(name)
+		if !ok {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if previous, ok := t.files[name]; ok && previous.modTime.Equal(info.ModTime()) && previous.size == info.Size() {
+			files[name] = previous
+			return nil
+		}
+		changed = append(changed, name)
+		loaded := loadedFile{modTime: info.ModTime(), size: info.Size(), translations: make(map[string]map[string]string)}
+		data, err := fs.ReadFile(t.fsys, name)
+		if err == nil {
+			err = LoadJSON(loaded.translations, data, file.Locale, file.Namespace)
+		}
+		if err != nil {
+			loaded.err = fmt.Errorf("%v: %w", name, err)
+		}
+		files[name] = loaded
+		return nil
+	})
+	if err != nil {
+		return changed, err
+	}
+	for name := range t.files {
+		if _, ok := files[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	if t.files != nil && len(changed) == 0 {
+		return nil, nil
+	}
+	sort.Strings(changed)
+	// Files are remembered even if invalid to report a failure once rather than on every poll
+	t.files = files
+	translations, err := mergeLoadedFiles(files)
+	if err == nil {
+		err = ValidateTranslations(translations, t.translatorOptions...)
+	}
+	for _, validate := range t.validators {
+		if err == nil {
+			err = validate(translations)
+		}
+	}
+	if err != nil {
+		return changed, err
+	}
+	t.swap(translations, nil)
+	return changed, nil
+}
+
+func mergeLoadedFiles(files map[string]loadedFile) (map[string]map[string]string, error) {
+	var errs []error
+	for _, file := range files {
+		if file.err != nil {
+			errs = append(errs, file.err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	translations := make(map[string]map[string]string)
+	for name, file := range files {
+		for key, texts := range file.translations {
+			merged := translations[key]
+			if merged == nil {
+				merged = make(map[string]string, len(texts))
+				translations[key] = merged
+			}
+			for locale, text := range texts {
+				if _, exists := merged[locale]; exists {
+					return nil, fmt.Errorf("%v: %w: key=%v&locale=%v", name, ErrDuplicateTranslation, key, locale)
+				}
+				merged[locale] = text
+			}
+		}
+	}
+	return translations, nil
+}
+
+// ValidateTranslations checks that messages of translations compile with engines set by the options,
+// e.g. WithMessageEngine & WithKeyEngine, so served translations never fail to parse when translated.
+// Placeholders are checked only for EnginePlaceholders as printf messages may have literal braces.
+func ValidateTranslations(translations map[string]map[string]string, options ...MapTranslatorOption) error {
+	validate := messageValidator(options...)
+	var errs []error
+	for key, texts := range translations {
+		for locale, text := range texts {
+			if err := validate(key, locale, text); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// messageValidator returns function checking a message as ValidateTranslations does
+func messageValidator(options ...MapTranslatorOption) func(key, locale, text string) error {
+	var t mapTranslator
+	for _, option := range options {
+		option(&t)
+	}
+	return func(key, locale, text string) error {
+		engine := t.engineFor(key)
+		m := compileMessage(engine, key, text, func() map[string]any {
+			funcs := messageFuncs(keysTranslator{}, locale)
+			for name, f := range t.funcs {
+				funcs[name] = f
+			}
+			return funcs
+		})
+		if m.tmplErr != nil { // set only for template engines & auto-detected templates
+			return &TranslationError{Key: key, Locale: locale, Err: &templateError{action: "parse", err: m.tmplErr}}
+		}
+		if engine == EnginePlaceholders && len(m.parseErrs) > 0 {
+			return &TranslationError{Key: key, Locale: locale, Err: errors.Join(m.parseErrs...)}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,246 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestReloadingTranslator(t *testing.T) {
+	// Prepare test data
+	modTime := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+	fsys := fstest.MapFS{
+		"en-US.json":         {Data: []byte(`{"hello": "Hello"}`), ModTime: modTime},
+		"billing/en-US.json": {Data: []byte(`{"title": "Billing"}`), ModTime: modTime},
+		"README.md":          {Data: []byte(`# Translations`), ModTime: modTime},
+	}
+	var events []ReloadEvent
+	translator, err := NewReloadingTranslator(context.Background(), fsys, "en-US",
+		WithReloadHandler(func(event ReloadEvent) {
+			events = append(events, event)
+		}),
+		WithReloadValidator(func(translations map[string]map[string]string) error {
+			if _, ok := translations["hello"]; !ok {
+				return errors.New("hello is required")
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := translator.Translate(NamespacedKey("billing", "title"), "en-US"); s != "Billing" {
+		t.Errorf("Unexpected translation: %q", s)
+	}
+
+	// No changes
+	if err = translator.Reload(); err != nil || len(events) != 0 {
+		t.Fatalf("Expected no reload events, got %v, %+v", err, events)
+	}
+
+	// Modified & added files
+	modTime = modTime.Add(time.Second)
+	fsys["en-US.json"] = &fstest.MapFile{Data: []byte(`{"hello": "Hi"}`), ModTime: modTime}
+	fsys["de-DE.json"] = &fstest.MapFile{Data: []byte(`{"hello": "Hallo"}`), ModTime: modTime}
+	if err = translator.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if s := translator.Translate("hello", "en-US"); s != "Hi" {
+		t.Errorf("Expected reloaded translation, got %q", s)
+	}
+	if s, _ := translator.TranslateE("hello", "de-DE"); s != "Hallo" {
+		t.Errorf("Expected translation of added file, got %q", s)
+	}
+	if result := translator.Lookup("hello", "de-DE"); !result.Found || result.ServedLocale != "de-DE" {
+		t.Errorf("Unexpected lookup result: %+v", result)
+	}
+	if len(events) != 1 || !reflect.DeepEqual(events[0].Changed, []string{"de-DE.json", "en-US.json"}) || events[0].Err != nil {
+		t.Errorf("Unexpected events: %+v", events)
+	}
+
+	// Invalid file keeps previous translations and is reported once
+	fsys["de-DE.json"] = &fstest.MapFile{Data: []byte(`{"hello": `), ModTime: modTime.Add(time.Second)}
+	if err = translator.Reload(); !errors.Is(err, ErrInvalidTranslationFile) {
+		t.Errorf("Expected invalid file error, got %v", err)
+	}
+	if s := translator.TranslateNoWarning("hello", "de-DE"); s != "Hallo" {
+		t.Errorf("Expected previous translation, got %q", s)
+	}
+	if err = translator.Reload(); err != nil || len(events) != 2 {
+		t.Errorf("Expected failure to be reported once, got %v, %d events", err, len(events))
+	}
+
+	// Failed validation
+	fsys["de-DE.json"] = &fstest.MapFile{Data: []byte(`{"hello": "Hallo {{.Name | unknown}}"}`), ModTime: modTime.Add(2 * time.Second)}
+	if err = translator.Reload(); !errors.Is(err, ErrTemplate) {
+		t.Errorf("Expected template error, got %v", err)
+	}
+	delete(fsys, "de-DE.json")
+	delete(fsys, "en-US.json")
+	if err = translator.Reload(); err == nil || err.Error() != "hello is required" {
+		t.Errorf("Expected custom validation error, got %v", err)
+	}
+	if s := translator.TranslateWithMap("hello", "en-US", nil); s != "Hi" {
+		t.Errorf("Expected previous translation, got %q", s)
+	}
+	if len(translator.Translations()) != 2 {
+		t.Errorf("Expected previous translations, got %v", translator.Translations())
+	}
+}
+
+func TestReloadingTranslator_Run(t *testing.T) {
+	// Prepare test data
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "en-US.json"), []byte(`{"hello": "Hello"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	reloaded := make(chan ReloadEvent, 1)
+	translator, err := NewReloadingTranslator(context.Background(), os.DirFS(dir), "en-US",
+		WithReloadInterval(time.Millisecond),
+		WithReloadHandler(func(event ReloadEvent) {
+			if event.Err == nil { // a partially written file may fail to load before the next poll
+				reloaded <- event
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		translator.Run(ctx)
+	}()
+	go func() { // readers are not blocked by reloads
+		defer wg.Done()
+		for ctx.Err() == nil {
+			translator.Translate("hello", "en-US")
+		}
+	}()
+	if err = os.WriteFile(filepath.Join(dir, "en-US.json"), []byte(`{"hello": "Hi"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		t.Error("Expected files to be reloaded")
+	}
+	cancel()
+	wg.Wait()
+	if s := translator.Translate("hello", "en-US"); s != "Hi" {
+		t.Errorf("Expected reloaded translation, got %q", s)
+	}
+}
+
+// infoErrorFS lists files of the embedded fstest.MapFS that fail to provide their info
+type infoErrorFS struct {
+	fstest.MapFS
+}
+
+func (fsys infoErrorFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := fsys.MapFS.ReadDir(name)
+	for i, entry := range entries {
+		entries[i] = infoErrorEntry{entry}
+	}
+	return entries, err
+}
+
+type infoErrorEntry struct {
+	fs.DirEntry
+}
+
+func (infoErrorEntry) Info() (fs.FileInfo, error) {
+	return nil, fs.ErrPermission
+}
+
+func TestNewReloadingTranslator_Error(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fsys     fs.FS
+		expected error
+	}{
+		{name: "Invalid file", fsys: fstest.MapFS{"en-US.json": {Data: []byte(`[]`)}}, expected: ErrInvalidTranslationFile},
+		{name: "Duplicate in namespace files", fsys: fstest.MapFS{
+			"billing/en-US.json": {Data: []byte(`{"title": "Billing"}`)},
+			"billing.en-US.json": {Data: []byte(`{"title": "Payments"}`)},
+		}, expected: ErrDuplicateTranslation},
+		{name: "Missing directory", fsys: os.DirFS(filepath.Join(t.TempDir(), "missing")), expected: fs.ErrNotExist},
+		{name: "No file info", fsys: infoErrorFS{fstest.MapFS{"en-US.json": {Data: []byte(`{}`)}}}, expected: fs.ErrPermission},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewReloadingTranslator(context.Background(), tc.fsys, "en-US"); !errors.Is(err, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestReloadingTranslator_messageEngine(t *testing.T) {
+	// Prepare test data
+	modTime := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+	fsys := fstest.MapFS{"en-US.json": {Data: []byte(`{"hello": "Hi {{.Name}}"}`), ModTime: modTime}}
+	logger := &mockLogger{}
+	translator, err := NewReloadingTranslator(context.Background(), fsys, "en-US",
+		WithReloadTranslatorOptions(WithMessageEngine(EngineTextTemplate), WithLogger(logger)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Texts are parsed by the engine of the served translator even if they don't look like templates
+	fsys["en-US.json"] = &fstest.MapFile{Data: []byte(`{"hello": "Hi {{ if }}"}`), ModTime: modTime.Add(time.Second)}
+	if err = translator.Reload(); !errors.Is(err, ErrTemplate) {
+		t.Errorf("Expected template error, got %v", err)
+	}
+	if s := translator.Translate("hello", "en-US", map[string]string{"Name": "Ann"}); s != "Hi Ann" {
+		t.Errorf("Expected previous translation, got %q", s)
+	}
+	if !logger.errorCalled {
+		t.Error("Expected failed reload to be logged by the logger of translator options")
+	}
+}
+
+func TestValidateTranslations(t *testing.T) {
+	testCases := []struct {
+		name    string
+		text    string
+		options []MapTranslatorOption
+		valid   bool
+	}{
+		{name: "Printf", text: "Hello, %v!", valid: true},
+		{name: "Printf text with a brace", text: "Press { to open %v", valid: true},
+		{name: "Printf text with a brace & printf engine", text: "Press { to open %v", valid: true,
+			options: []MapTranslatorOption{WithMessageEngine(EnginePrintf)}},
+		{name: "Template", text: "Hello, {{.Name}}!", valid: true},
+		{name: "Template with unknown func", text: "Hello, {{.Name | unknown}}!"},
+		{name: "Template with custom func", text: "Hello, {{.Name | upper}}!", valid: true,
+			options: []MapTranslatorOption{WithTemplateFuncs(map[string]any{"upper": func(s string) string { return s }})}},
+		{name: "Text template", text: "Hello, {{ if }}!", options: []MapTranslatorOption{WithMessageEngine(EngineTextTemplate)}},
+		{name: "Text template of a key", text: "Hello, {{ if }}!", options: []MapTranslatorOption{WithKeyEngine("hello", EngineTextTemplate)}},
+		{name: "Placeholders", text: "Hello, {name}!", options: []MapTranslatorOption{WithMessageEngine(EnginePlaceholders)}, valid: true},
+		{name: "Malformed placeholder", text: "Hello, {name, select}!", options: []MapTranslatorOption{WithMessageEngine(EnginePlaceholders)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateTranslations(map[string]map[string]string{"hello": {"en-US": tc.text}}, tc.options...)
+			if tc.valid && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package i18n
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RemoteOption configures RemoteTranslator
+type RemoteOption func(t *RemoteTranslator)
+
+// WithRemoteHTTPClient sets HTTP client used to fetch bundles, default is http.DefaultClient
+func WithRemoteHTTPClient(client *http.Client) RemoteOption {
+	return func(t *RemoteTranslator) {
+		t.client = client
+	}
+}
+
+// WithRemoteLocales sets locales of fetched bundles, default is the default locale
+func WithRemoteLocales(locales ...string) RemoteOption {
+	return func(t *RemoteTranslator) {
+		t.locales = locales
+	}
+}
+
+// WithRemoteNamespaces sets namespaces of fetched bundles, default is no namespace
+func WithRemoteNamespaces(namespaces ...string) RemoteOption {
+	return func(t *RemoteTranslator) {
+		t.namespaces = namespaces
+	}
+}
+
+// WithRemoteCacheDir sets directory where fetched bundles are cached to be served on cold starts
+func WithRemoteCacheDir(dir string) RemoteOption {
+	return func(t *RemoteTranslator) {
+		t.cacheDir = dir
+	}
+}
+
+// WithRemoteDefaults sets translator of embedded defaults used for texts missing in fetched & cached bundles
+func WithRemoteDefaults(defaults Translator) RemoteOption {
+	return func(t *RemoteTranslator) {
+		t.defaults = defaults
+	}
+}
+
+// WithRemoteRefreshInterval sets how often Run refreshes bundles, default is 5 minutes
+func WithRemoteRefreshInterval(interval time.Duration) RemoteOption {
+	interval = positiveInterval("WithRemoteRefreshInterval", interval)
+	return func(t *RemoteTranslator) {
+		t.interval = interval
+	}
+}
+
+// WithRemoteTranslatorOptions sets options of translators created for fetched bundles that are validated with them,
+// a logger set by WithLogger also logs failures of cache & Run
+func WithRemoteTranslatorOptions(options ...MapTranslatorOption) RemoteOption {
+	return func(t *RemoteTranslator) {
+		t.translatorOptions = options
+	}
+}
+
+// RemoteTranslator serves bundles of a translation service, e.g. the one served by BundleHandler,
+// fetched from baseURL + BundlePath(locale, namespace). Bundles are requested with If-None-Match & gzip encoding,
+// cached to disk for cold starts and refreshed by Refresh or while Run is polling. Texts missing in bundles,
+// e.g. when the service is unavailable and nothing is cached, are served from embedded defaults. It is safe for concurrent use.
+type RemoteTranslator struct {
+	swappableTranslator
+	baseURL    string
+	client     *http.Client
+	locales    []string
+	namespaces []string
+	cacheDir   string
+	defaults   Translator
+
+	mutex   sync.Mutex // serializes refreshes
+	bundles map[string]cachedBundle
+}
+
+// cachedBundle is a bundle with its ETag as stored in the cache directory
+type cachedBundle struct {
+	ETag   string `json:"etag,omitempty"`
+	Bundle Bundle `json:"bundle"`
+}
+
+var _ Translator = (*RemoteTranslator)(nil)
+
+// NewRemoteTranslator creates translator serving cached bundles if any, bundles are fetched by Refresh or Run
+func NewRemoteTranslator(c context.Context, baseURL, defaultLocale string, options ...RemoteOption) *RemoteTranslator {
+	t := &RemoteTranslator{
+		swappableTranslator: swappableTranslator{c: c, defaultLocale: defaultLocale, interval: 5 * time.Minute},
+		baseURL:             baseURL,
+		client:              http.DefaultClient,
+		locales:             []string{defaultLocale},
+		namespaces:          []string{""},
+		bundles:             make(map[string]cachedBundle),
+	}
+	for _, option := range options {
+		option(t)
+	}
+	for _, locale := range t.locales {
+		for _, namespace := range t.namespaces {
+			if bundle, err := t.readCache(locale, namespace); err != nil {
+				logAttrs(c, t.logger(), slog.LevelWarn, "Failed to read cached bundle",
+					slog.String(LogAttrLocale, locale), slog.String("namespace", namespace), slog.Any("error", err))
+			} else if bundle != nil {
+				t.bundles[BundlePath(locale, namespace)] = *bundle
+			}
+		}
+	}
+	t.store()
+	return t
+}
+
+// Run refreshes bundles immediately and then periodically until the context is done
+func (t *RemoteTranslator) Run(ctx context.Context) {
+	refresh := func() {
+		if err := t.Refresh(ctx); err != nil {
+			logAttrs(t.c, t.logger(), slog.LevelError, "Failed to refresh bundles", slog.Any("error", err))
+		}
+	}
+	refresh()
+	t.run(ctx, refresh)
+}
+
+// Refresh fetches changed bundles, bundles that fail to be fetched keep being served from memory or cache
+func (t *RemoteTranslator) Refresh(ctx context.Context) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	var errs []error
+	var changed bool
+	for _, locale := range t.locales {
+		for _, namespace := range t.namespaces {
+			name := BundlePath(locale, namespace)
+			previous, cached := t.bundles[name]
+			bundle, err := t.fetch(ctx, locale, namespace, previous.ETag)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if bundle == nil { // not modified
+				continue
+			}
+			t.bundles[name] = *bundle
+			if !cached || bundle.Bundle.Version != previous.Bundle.Version {
+				changed = true
+			}
+			if err = t.writeCache(name, *bundle); err != nil {
+				logAttrs(t.c, t.logger(), slog.LevelWarn, "Failed to cache bundle", slog.String("bundle", name), slog.Any("error", err))
+			}
+		}
+	}
+	if changed {
+		t.store()
+	}
+	return errors.Join(errs...)
+}
+
+// Versions returns versions of served bundles by their paths
+func (t *RemoteTranslator) Versions() map[string]string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	versions := make(map[string]string, len(t.bundles))
+	for name, bundle := range t.bundles {
+		versions[name] = bundle.Bundle.Version
+	}
+	return versions
+}
+
+// fetch returns nil bundle if it has not been modified since the ETag
+func (t *RemoteTranslator) fetch(ctx context.Context, locale, namespace, etag string) (_ *cachedBundle, err error) {
+	bundleURL, err := url.JoinPath(t.baseURL, BundlePath(locale, namespace))
+	var request *http.Request
+	if err == nil {
+		request, err = http.NewRequestWithContext(ctx, http.MethodGet, bundleURL, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Accept-Encoding", "gzip")
+	if etag != "" {
+		request.Header.Set("If-None-Match", etag)
+	}
+	response, err := t.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, response.Body.Close())
+	}()
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed to fetch %v: %v", bundleURL, response.Status)
+	}
+	var body io.Reader = response.Body
+	if response.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(response.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %v: %w", bundleURL, err)
+		}
+		defer func() {
+			err = errors.Join(err, gzipReader.Close())
+		}()
+		body = gzipReader
+	}
+	bundle := &cachedBundle{ETag: response.Header.Get("ETag")}
+	if err = json.NewDecoder(body).Decode(&bundle.Bundle); err != nil {
+		return nil, fmt.Errorf("failed to fetch %v: %w: %v", bundleURL, ErrInvalidBundle, err)
+	}
+	if err = bundle.Bundle.validate(locale, namespace, t.translatorOptions...); err != nil {
+		return nil, fmt.Errorf("failed to fetch %v: %w", bundleURL, err)
+	}
+	return bundle, nil
+}
+
+func (t *RemoteTranslator) readCache(locale, namespace string) (*cachedBundle, error) {
+	if t.cacheDir == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(filepath.Join(t.cacheDir, filepath.FromSlash(BundlePath(locale, namespace))))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var bundle cachedBundle
+	if err = json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	if err = bundle.Bundle.validate(locale, namespace, t.translatorOptions...); err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+// writeCache replaces cached bundle atomically so a crash never leaves a partially written file
+func (t *RemoteTranslator) writeCache(name string, bundle cachedBundle) error {
+	if t.cacheDir == "" {
+		return nil
+	}
+	fileName := filepath.Join(t.cacheDir, filepath.FromSlash(name))
+	var file *os.File
+	err := os.MkdirAll(filepath.Dir(fileName), 0o755)
+	if err == nil {
+		file, err = os.CreateTemp(filepath.Dir(fileName), filepath.Base(fileName)+".*.tmp")
+	}
+	if err != nil {
+		return err
+	}
+	err = json.NewEncoder(file).Encode(bundle)
+	if err = errors.Join(err, file.Close()); err == nil {
+		err = os.Rename(file.Name(), fileName)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+	}
+	return err
+}
+
+func (t *RemoteTranslator) store() {
+	translations := make(map[string]map[string]string)
+	names := make([]string, 0, len(t.bundles))
+	for name := range t.bundles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t.bundles[name].Bundle.addTo(translations)
+	}
+	translator := t.newTranslator(translations)
+	if t.defaults != nil {
+		translator = NewLayeredTranslator(t.c, []Layer{{Name: "remote", Translator: translator}, {Name: "defaults", Translator: t.defaults}}, WithLayeredLogger(t.logger()))
+	}
+	t.swap(translations, translator)
+}
@@ -0,0 +1,356 @@
+package i18n
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// bundleTestServer serves bundles by their paths with ETags being versions
+type bundleTestServer struct {
+	mutex       sync.Mutex
+	bundles     map[string]Bundle
+	unavailable bool
+	requests    []*http.Request
+	*httptest.Server
+}
+
+func newBundleTestServer(t *testing.T, bundles ...Bundle) *bundleTestServer {
+	s := &bundleTestServer{bundles: make(map[string]Bundle)}
+	for _, bundle := range bundles {
+		s.bundles[BundlePath(bundle.Locale, bundle.Namespace)] = bundle
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		s.requests = append(s.requests, r)
+		if s.unavailable {
+			http.Error(w, "maintenance", http.StatusServiceUnavailable)
+			return
+		}
+		bundle, ok := s.bundles[strings.TrimPrefix(r.URL.Path, "/i18n/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		etag := `"` + bundle.Version + `"`
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			_ = json.NewEncoder(w).Encode(bundle)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gzipWriter := gzip.NewWriter(w)
+		_ = json.NewEncoder(gzipWriter).Encode(bundle)
+		_ = gzipWriter.Close()
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *bundleTestServer) setBundle(bundle Bundle) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.bundles[BundlePath(bundle.Locale, bundle.Namespace)] = bundle
+}
+
+func (s *bundleTestServer) setUnavailable(unavailable bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.unavailable = unavailable
+}
+
+// lastRequest returns the last request of a bundle
+func (s *bundleTestServer) lastRequest(name string) (request *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, r := range s.requests {
+		if r.URL.Path == "/i18n/"+name {
+			request = r
+		}
+	}
+	return request
+}
+
+// requestCount returns number of requests of a bundle
+func (s *bundleTestServer) requestCount(name string) (n int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, r := range s.requests {
+		if r.URL.Path == "/i18n/"+name {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRemoteTranslator_Refresh(t *testing.T) {
+	// Prepare test data
+	server := newBundleTestServer(t,
+		Bundle{Locale: "en-US", Version: "1", Translations: map[string]string{"title": "Title"}},
+		Bundle{Locale: "de-DE", Version: "1", Translations: map[string]string{"title": "Titel"}},
+		Bundle{Locale: "en-US", Namespace: "billing", Version: "1", Translations: map[string]string{"total": "Total"}},
+	)
+	ctx := context.Background()
+	defaults := NewMapTranslator(ctx, "en-US", map[string]map[string]string{
+		"title":                           {"en-US": "Default title"},
+		NamespacedKey("billing", "total"): {"en-US": "Default total"},
+	})
+	translator := NewRemoteTranslator(ctx, server.URL+"/i18n", "en-US",
+		WithRemoteHTTPClient(server.Client()),
+		WithRemoteLocales("en-US", "de-DE"),
+		WithRemoteNamespaces("", "billing"),
+		WithRemoteDefaults(defaults),
+	)
+
+	if s := translator.Translate("title", "en-US"); s != "Default title" {
+		t.Errorf("Expected default before refresh, got %q", s)
+	}
+	if err := translator.Refresh(ctx); err == nil || !strings.Contains(err.Error(), "billing/de-DE.json") {
+		t.Errorf("Expected error for missing bundle, got %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		key      string
+		locale   string
+		expected string
+	}{
+		{name: "Fetched", key: "title", locale: "de-DE", expected: "Titel"},
+		{name: "Namespace", key: NamespacedKey("billing", "total"), locale: "en-US", expected: "Total"},
+		{name: "Missing bundle falls back", key: NamespacedKey("billing", "total"), locale: "de-DE", expected: "Total"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if s := translator.Translate(tc.key, tc.locale); s != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, s)
+			}
+		})
+	}
+
+	// Not modified bundles are not downloaded again
+	_ = translator.Refresh(ctx)
+	if etag := server.lastRequest("en-US.json").Header.Get("If-None-Match"); etag != `"1"` {
+		t.Errorf("Expected If-None-Match header, got %q", etag)
+	}
+
+	server.setBundle(Bundle{Locale: "de-DE", Version: "2", Translations: map[string]string{"title": "Neuer Titel"}})
+	_ = translator.Refresh(ctx)
+	if s := translator.Translate("title", "de-DE"); s != "Neuer Titel" {
+		t.Errorf("Expected new version, got %q", s)
+	}
+	if version := translator.Versions()["de-DE.json"]; version != "2" {
+		t.Errorf("Expected version 2, got %q", version)
+	}
+
+	// Invalid bundles and failures of the service keep served translations
+	server.setBundle(Bundle{Locale: "fr-FR", Version: "3"})
+	server.setBundle(Bundle{Locale: "de-DE", Version: "3", Translations: map[string]string{"title": "{{.Name | unknown}}"}})
+	if err := translator.Refresh(ctx); err == nil {
+		t.Error("Expected error for invalid bundle")
+	}
+	server.setUnavailable(true)
+	if err := translator.Refresh(ctx); err == nil {
+		t.Error("Expected error for unavailable service")
+	}
+	if s := translator.Translate("title", "de-DE"); s != "Neuer Titel" {
+		t.Errorf("Expected previous version to be kept, got %q", s)
+	}
+}
+
+func TestRemoteTranslator_cache(t *testing.T) {
+	// Prepare test data
+	server := newBundleTestServer(t, Bundle{Locale: "en-US", Version: "1", Translations: map[string]string{"title": "Title"}})
+	ctx := context.Background()
+	cacheDir := t.TempDir()
+	defaults := NewMapTranslator(ctx, "en-US", map[string]map[string]string{
+		"title": {"en-US": "Default title"},
+	})
+	newTranslator := func() *RemoteTranslator {
+		return NewRemoteTranslator(ctx, server.URL+"/i18n", "en-US",
+			WithRemoteHTTPClient(server.Client()),
+			WithRemoteCacheDir(cacheDir),
+			WithRemoteDefaults(defaults),
+		)
+	}
+	if err := newTranslator().Refresh(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Cold start while the service is unavailable
+	server.setUnavailable(true)
+	translator := newTranslator()
+	if s := translator.Translate("title", "en-US"); s != "Title" {
+		t.Errorf("Expected cached translation, got %q", s)
+	}
+	if err := translator.Refresh(ctx); err == nil {
+		t.Error("Expected error for unavailable service")
+	}
+	if etag := server.lastRequest("en-US.json").Header.Get("If-None-Match"); etag != `"1"` {
+		t.Errorf("Expected ETag of cached bundle, got %q", etag)
+	}
+	if s := translator.Translate("title", "en-US"); s != "Title" {
+		t.Errorf("Expected cached translation to be kept, got %q", s)
+	}
+}
+
+func TestRemoteTranslator_translatorOptions(t *testing.T) {
+	// Prepare test data
+	server := newBundleTestServer(t, Bundle{Locale: "en-US", Version: "1", Translations: map[string]string{"hello": "Hello, {name, select}!"}})
+	ctx := context.Background()
+	cacheDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(cacheDir, "en-US.json"), []byte(`{"bundle": {"locale": "de-DE"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	logger := &mockLogger{}
+	translator := NewRemoteTranslator(ctx, server.URL+"/i18n", "en-US",
+		WithRemoteHTTPClient(server.Client()),
+		WithRemoteCacheDir(cacheDir),
+		WithRemoteTranslatorOptions(WithMessageEngine(EnginePlaceholders), WithLogger(logger)),
+	)
+	if !logger.warningCalled {
+		t.Error("Expected invalid cached bundle to be logged by the logger of translator options")
+	}
+
+	// The bundle is valid for printf messages but not for the placeholders engine of served translators
+	if err := translator.Refresh(ctx); !errors.Is(err, ErrInvalidBundle) {
+		t.Errorf("Expected ErrInvalidBundle, got %v", err)
+	}
+	if err := NewRemoteTranslator(ctx, server.URL+"/i18n", "en-US", WithRemoteHTTPClient(server.Client())).Refresh(ctx); err != nil {
+		t.Errorf("Unexpected error without translator options: %v", err)
+	}
+}
+
+func TestRemoteTranslator_fetchErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     string
+		gzipped  bool
+		expected error
+	}{
+		{name: "Malformed JSON", body: `{"locale": `, expected: ErrInvalidBundle},
+		{name: "Malformed gzip", body: `{"locale": "en-US"}`, gzipped: true, expected: gzip.ErrHeader},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.gzipped {
+					w.Header().Set("Content-Encoding", "gzip")
+				}
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+			translator := NewRemoteTranslator(context.Background(), server.URL, "en-US", WithRemoteHTTPClient(server.Client()))
+			if err := translator.Refresh(context.Background()); !errors.Is(err, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+
+	if err := NewRemoteTranslator(context.Background(), "http://[::1", "en-US").Refresh(context.Background()); err == nil {
+		t.Error("Expected error for malformed base URL")
+	}
+}
+
+func TestRemoteTranslator_cacheErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		prepare func(dir string) (cacheDir string, err error)
+	}{
+		{name: "Cache directory is a file", prepare: func(dir string) (string, error) {
+			cacheDir := filepath.Join(dir, "cache")
+			return cacheDir, os.WriteFile(cacheDir, nil, 0o644)
+		}},
+		{name: "Cached bundle is a directory", prepare: func(dir string) (string, error) {
+			return dir, os.MkdirAll(filepath.Join(dir, "en-US.json", "bundle"), 0o755)
+		}},
+		{name: "Malformed cached bundle", prepare: func(dir string) (string, error) {
+			return dir, os.WriteFile(filepath.Join(dir, "en-US.json"), []byte(`{`), 0o644)
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newBundleTestServer(t, Bundle{Locale: "en-US", Version: "1", Translations: map[string]string{"title": "Title"}})
+			cacheDir, err := tc.prepare(t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			logger := &mockLogger{}
+			ctx := context.Background()
+			translator := NewRemoteTranslator(ctx, server.URL+"/i18n", "en-US",
+				WithRemoteHTTPClient(server.Client()),
+				WithRemoteCacheDir(cacheDir),
+				WithRemoteTranslatorOptions(WithLogger(logger)),
+			)
+			if !logger.warningCalled {
+				t.Error("Expected failure to read cached bundle to be logged")
+			}
+			if err = translator.Refresh(ctx); err != nil {
+				t.Errorf("Expected failures of cache to not fail refresh, got %v", err)
+			}
+			if s := translator.Translate("title", "en-US"); s != "Title" {
+				t.Errorf("Expected fetched translation, got %q", s)
+			}
+		})
+	}
+}
+
+func TestRemoteTranslator_Run(t *testing.T) {
+	// Prepare test data
+	server := newBundleTestServer(t, Bundle{Locale: "en-US", Version: "1", Translations: map[string]string{"title": "Title"}})
+	ctx, cancel := context.WithCancel(context.Background())
+	logger := &mockLogger{}
+	translator := NewRemoteTranslator(ctx, server.URL+"/i18n", "en-US",
+		WithRemoteHTTPClient(server.Client()),
+		WithRemoteLocales("en-US", "de-DE"),
+		WithRemoteRefreshInterval(time.Millisecond),
+		WithRemoteTranslatorOptions(WithLogger(logger)),
+	)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		translator.Run(ctx)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for translator.TranslateNoWarning("title", "en-US") != "Title" || server.requestCount("de-DE.json") < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected bundles to be fetched periodically")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+	if !logger.errorCalled {
+		t.Error("Expected failed refresh of the missing bundle to be logged")
+	}
+}
+
+func TestWithRemoteRefreshInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected panic for interval %v", interval)
+				}
+			}()
+			WithRemoteRefreshInterval(interval)
+		}()
+	}
+}
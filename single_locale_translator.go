@@ -2,16 +2,17 @@ package i18n
 
 type theSingleLocaleTranslator struct {
 	locale Locale
+	code   string // locale code passed to Translator, with a formality variant if any
 	Translator
 }
 
 func (t theSingleLocaleTranslator) TranslateWithMap(key string, args map[string]string) string {
-	var s = t.Translator.Translate(key, t.locale.Code5)
-	return placeMapValues(s, args)
+	var s = t.Translator.Translate(key, t.code)
+	return placeMapValues(t.code, s, args)
 }
 
 func (t theSingleLocaleTranslator) Translate(key string, args ...any) string {
-	return t.Translator.Translate(key, t.locale.Code5, args...)
+	return t.Translator.Translate(key, t.code, args...)
 }
 
 func (t theSingleLocaleTranslator) Locale() Locale {
@@ -19,15 +20,38 @@ func (t theSingleLocaleTranslator) Locale() Locale {
 }
 
 func (t theSingleLocaleTranslator) TranslateNoWarning(key string, args ...any) string {
-	return t.Translator.TranslateNoWarning(key, t.locale.Code5, args...)
+	return t.Translator.TranslateNoWarning(key, t.code, args...)
+}
+
+func (t theSingleLocaleTranslator) TranslateE(key string, args ...any) (string, error) {
+	return t.Translator.TranslateE(key, t.code, args...)
+}
+
+func (t theSingleLocaleTranslator) Lookup(key string) LookupResult {
+	return t.Translator.Lookup(key, t.code)
 }
 
 var _ SingleLocaleTranslator = (*theSingleLocaleTranslator)(nil)
 
+// SingleLocaleTranslatorOption configures translator created by NewSingleMapTranslator
+type SingleLocaleTranslatorOption func(t *theSingleLocaleTranslator)
+
+// WithFormalityVariant makes translator use formality variant of the locale, see VariantLocale
+func WithFormalityVariant(formality Formality) SingleLocaleTranslatorOption {
+	return func(t *theSingleLocaleTranslator) {
+		t.code = VariantLocale(t.locale.Code5, formality)
+	}
+}
+
 // NewSingleMapTranslator creates new single map translator
-func NewSingleMapTranslator(locale Locale, translator Translator) SingleLocaleTranslator {
-	return theSingleLocaleTranslator{
+func NewSingleMapTranslator(locale Locale, translator Translator, options ...SingleLocaleTranslatorOption) SingleLocaleTranslator {
+	t := theSingleLocaleTranslator{
 		locale:     locale,
+		code:       locale.Code5,
 		Translator: translator,
 	}
+	for _, option := range options {
+		option(&t)
+	}
+	return t
 }
@@ -42,6 +42,20 @@ func (m mockTranslator) TranslateNoWarning(key, locale string, args ...any) stri
 	return m.Translate(key, locale, args...)
 }
 
+func (m mockTranslator) Lookup(key, locale string) LookupResult {
+	if translation, ok := m.translations[key][locale]; ok {
+		return LookupResult{Key: key, Locale: locale, Text: translation, ServedLocale: locale, Found: true, FallbackPath: []string{locale}}
+	}
+	return notFoundResult(key, locale)
+}
+
+func (m mockTranslator) TranslateE(key, locale string, args ...any) (string, error) {
+	if _, ok := m.translations[key][locale]; !ok {
+		return "", ErrKeyNotFound
+	}
+	return m.Translate(key, locale, args...), nil
+}
+
 func TestNewSingleMapTranslator(t *testing.T) {
 	// Prepare test data
 	locale := Locale{Code5: "en-US", NativeTitle: "English", EnglishTitle: "English", FlagIcon: "🇺🇸"}
@@ -13,11 +13,10 @@ type SingleLocaleTranslatorWithBackup struct {
 }
 
 func (t SingleLocaleTranslatorWithBackup) TranslateWithMap(key string, args map[string]string) string {
-	s := t.PrimaryTranslator.TranslateWithMap(key, args)
-	if s == "" {
-		s = t.BackupTranslator.TranslateWithMap(key, args)
+	if t.PrimaryTranslator.Lookup(key).Found {
+		return t.PrimaryTranslator.TranslateWithMap(key, args)
 	}
-	return s
+	return t.BackupTranslator.TranslateWithMap(key, args)
 }
 
 // NewSingleLocaleTranslatorWithBackup creates SingleLocaleTranslatorWithBackup
@@ -30,12 +29,24 @@ func (t SingleLocaleTranslatorWithBackup) Locale() Locale {
 	return t.PrimaryTranslator.Locale()
 }
 
+// Lookup looks up the key in primary translator and then in backup one
+func (t SingleLocaleTranslatorWithBackup) Lookup(key string) LookupResult {
+	primary := t.PrimaryTranslator.Lookup(key)
+	if primary.Found {
+		return primary
+	}
+	backup := t.BackupTranslator.Lookup(key)
+	backup.Locale = primary.Locale
+	backup.FallbackPath = append(primary.FallbackPath, backup.FallbackPath...)
+	return backup
+}
+
 // Translate translates
 func (t SingleLocaleTranslatorWithBackup) Translate(key string, args ...any) string {
-	result := t.PrimaryTranslator.Translate(key, args...)
-	if result == key || result == "" {
-		result = t.BackupTranslator.Translate(key, args...)
+	if t.PrimaryTranslator.Lookup(key).Found {
+		return t.PrimaryTranslator.Translate(key, args...)
 	}
+	result := t.BackupTranslator.Translate(key, args...)
 	if result == "" {
 		result = key + fmt.Sprintf("(args=%+v)", args)
 	}
@@ -44,9 +55,17 @@ func (t SingleLocaleTranslatorWithBackup) Translate(key string, args ...any) str
 
 // TranslateNoWarning translates and does not log warning if translation not found
 func (t SingleLocaleTranslatorWithBackup) TranslateNoWarning(key string, args ...any) string {
-	result := t.PrimaryTranslator.TranslateNoWarning(key, args...)
-	if result == key {
-		result = t.BackupTranslator.TranslateNoWarning(key, args...)
+	if t.PrimaryTranslator.Lookup(key).Found {
+		return t.PrimaryTranslator.TranslateNoWarning(key, args...)
 	}
-	return result
+	return t.BackupTranslator.TranslateNoWarning(key, args...)
+}
+
+// TranslateE translates by primary translator and falls back to backup one if translation is not found
+func (t SingleLocaleTranslatorWithBackup) TranslateE(key string, args ...any) (string, error) {
+	s, err := t.PrimaryTranslator.TranslateE(key, args...)
+	if IsNotFound(err) {
+		return t.BackupTranslator.TranslateE(key, args...)
+	}
+	return s, err
 }
@@ -1,6 +1,7 @@
 package i18n
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -30,6 +31,23 @@ func (m mockSingleLocaleTranslator) TranslateNoWarning(key string, _ ...any) str
 	return key // Return key if no translation found
 }
 
+func (m mockSingleLocaleTranslator) TranslateE(key string, _ ...any) (string, error) {
+	if result, ok := m.translateResult[key]; ok {
+		return result, nil
+	}
+	return "", ErrKeyNotFound
+}
+
+// Lookup treats non-empty results of any method as found translations
+func (m mockSingleLocaleTranslator) Lookup(key string) LookupResult {
+	for _, results := range []map[string]string{m.translateResult, m.translateNoWarnResult, m.translateWithMapResult} {
+		if result := results[key]; result != "" {
+			return LookupResult{Key: key, Locale: m.locale.Code5, Text: result, ServedLocale: m.locale.Code5, Found: true, FallbackPath: []string{m.locale.Code5}}
+		}
+	}
+	return notFoundResult(key, m.locale.Code5)
+}
+
 func (m mockSingleLocaleTranslator) TranslateWithMap(key string, _ map[string]string) string {
 	if result, ok := m.translateWithMapResult[key]; ok {
 		return result
@@ -280,3 +298,25 @@ func TestSingleLocaleTranslatorWithBackup_TranslateWithMap(t *testing.T) {
 		})
 	}
 }
+
+func TestSingleLocaleTranslatorWithBackup_TranslateE(t *testing.T) {
+	primary := mockSingleLocaleTranslator{
+		locale:          Locale{Code5: "en-US"},
+		translateResult: map[string]string{"greeting": "Hello"},
+	}
+	backup := mockSingleLocaleTranslator{
+		locale:          Locale{Code5: "fr-FR"},
+		translateResult: map[string]string{"greeting": "Bonjour", "bye": "Au revoir"},
+	}
+	translator := NewSingleLocaleTranslatorWithBackup(primary, backup)
+
+	if result, err := translator.TranslateE("greeting"); err != nil || result != "Hello" {
+		t.Errorf("Expected primary translation, got %q, %v", result, err)
+	}
+	if result, err := translator.TranslateE("bye"); err != nil || result != "Au revoir" {
+		t.Errorf("Expected backup translation, got %q, %v", result, err)
+	}
+	if _, err := translator.TranslateE("unknown"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Expected ErrKeyNotFound, got %v", err)
+	}
+}
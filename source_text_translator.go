@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// SourceTextID returns a stable ID of a source text with an optional context to be used as a translation key
+func SourceTextID(source, context string) string {
+	hash := sha256.Sum256([]byte(ContextKey(source, context)))
+	return hex.EncodeToString(hash[:8])
+}
+
+// AddSourceText adds translation of a source text to translations in the format used by NewMapTranslator,
+// e.g. when importing msgid/msgstr pairs produced by extraction tools
+func AddSourceText(translations map[string]map[string]string, locale, source, context, translation string) {
+	id := SourceTextID(source, context)
+	if translations[id] == nil {
+		translations[id] = make(map[string]string)
+	}
+	translations[id][locale] = translation
+}
+
+// NewSourceTextTranslator creates a gettext-style translator where keys are source texts (see ContextKey)
+// in the source locale. Translations are looked up by SourceTextID() and then by the source text itself,
+// and the source text is used if there is no translation, so missing translations are not errors.
+func NewSourceTextTranslator(translator Translator, sourceLocale string) Translator {
+	return sourceTextTranslator{translator: translator, sourceLocale: sourceLocale}
+}
+
+type sourceTextTranslator struct {
+	translator   Translator
+	sourceLocale string
+}
+
+var _ Translator = (*sourceTextTranslator)(nil)
+
+// Lookup returns translation or the source text served for the source locale
+func (t sourceTextTranslator) Lookup(key, locale string) LookupResult {
+	if _, result, found := t.find(key, locale); found {
+		return result
+	}
+	source, _ := SplitContextKey(key)
+	var path []string
+	if baseLocale(locale) != t.sourceLocale {
+		path = append(path, locale)
+	}
+	path = append(path, t.sourceLocale)
+	return LookupResult{Key: key, Locale: locale, Text: source, ServedLocale: t.sourceLocale, Found: true, FallbackPath: path}
+}
+
+// find returns key the wrapped translator has translation for, either the ID of the source text or the key itself.
+// Fallbacks of the wrapped translator to its default locale are ignored as the source text is the fallback.
+func (t sourceTextTranslator) find(key, locale string) (string, LookupResult, bool) {
+	if baseLocale(locale) == t.sourceLocale {
+		return "", LookupResult{}, false
+	}
+	source, context := SplitContextKey(key)
+	for _, k := range []string{SourceTextID(source, context), key} {
+		if result := t.translator.Lookup(k, locale); result.Found && !result.Fallback() {
+			return k, result, true
+		}
+	}
+	return "", LookupResult{}, false
+}
+
+func (t sourceTextTranslator) Translate(key, locale string, args ...any) string {
+	if k, _, found := t.find(key, locale); found {
+		return t.translator.Translate(k, locale, args...)
+	}
+	s, _ := t.formatSource(key, locale, args...)
+	return s
+}
+
+func (t sourceTextTranslator) TranslateNoWarning(key, locale string, args ...any) string {
+	if k, _, found := t.find(key, locale); found {
+		return t.translator.TranslateNoWarning(k, locale, args...)
+	}
+	s, _ := t.formatSource(key, locale, args...)
+	return s
+}
+
+func (t sourceTextTranslator) TranslateWithMap(key, locale string, args map[string]string) string {
+	if k, _, found := t.find(key, locale); found {
+		return t.translator.TranslateWithMap(k, locale, args)
+	}
+	source, _ := SplitContextKey(key)
+	return placeMapValues(locale, source, args)
+}
+
+func (t sourceTextTranslator) TranslateE(key, locale string, args ...any) (string, error) {
+	if k, _, found := t.find(key, locale); found {
+		return t.translator.TranslateE(k, locale, args...)
+	}
+	return t.formatSource(key, locale, args...)
+}
+
+// formatSource formats the source text of a key that has no translation
+func (t sourceTextTranslator) formatSource(key, locale string, args ...any) (string, error) {
+	source, _ := SplitContextKey(key)
+	return sprintf(key, locale, source, args...)
+}
@@ -0,0 +1,123 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSourceTextID(t *testing.T) {
+	if SourceTextID("Open", "") != SourceTextID("Open", "") {
+		t.Error("Expected ID to be stable")
+	}
+	if SourceTextID("Open", "") == SourceTextID("Open", "verb") {
+		t.Error("Expected context to change ID")
+	}
+	if id := SourceTextID("Open", ""); len(id) != 16 {
+		t.Errorf("Expected 16 hex chars, got %q", id)
+	}
+}
+
+func TestSourceTextTranslator_TranslateE(t *testing.T) {
+	// Prepare test data
+	translations := map[string]map[string]string{
+		"Hello, %s!": {"de-DE": "Hallo, %s!"}, // msgid as is, e.g. imported from a .po file
+	}
+	AddSourceText(translations, "de-DE", "Open", "verb", "Öffnen")
+	AddSourceText(translations, "de-DE", "Open", "", "Offen")
+	translator := NewSourceTextTranslator(NewMapTranslator(context.Background(), "en-US", translations), "en-US")
+
+	testCases := []struct {
+		name     string
+		key      string
+		locale   string
+		args     []any
+		expected string
+		err      error
+	}{
+		{name: "Source locale", key: "Hello, %s!", locale: "en-US", args: []any{"Jack"}, expected: "Hello, Jack!"},
+		{name: "By source text", key: "Hello, %s!", locale: "de-DE", args: []any{"Jack"}, expected: "Hallo, Jack!"},
+		{name: "By ID", key: "Open", locale: "de-DE", expected: "Offen"},
+		{name: "By ID with context", key: ContextKey("Open", "verb"), locale: "de-DE", expected: "Öffnen"},
+		{name: "Context in source locale", key: ContextKey("Open", "verb"), locale: "en-US", expected: "Open"},
+		{name: "Missing translation", key: "Close", locale: "de-DE", expected: "Close"},
+		{name: "Bad args", key: "Close", locale: "de-DE", args: []any{1}, expected: "Close%!(EXTRA int=1)", err: ErrBadArgs},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := translator.TranslateE(tc.key, tc.locale, tc.args...)
+			if !errors.Is(err, tc.err) {
+				t.Errorf("Expected error %v, got %v", tc.err, err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestSourceTextTranslator_TranslateWithMap(t *testing.T) {
+	translator := NewSourceTextTranslator(NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"Hello, {name}!": {"de-DE": "Hallo, {name}!"},
+	}), "en-US")
+	if result := translator.TranslateWithMap("Hello, {name}!", "de-DE", map[string]string{"name": "Jack"}); result != "Hallo, Jack!" {
+		t.Errorf("Unexpected result: %q", result)
+	}
+	if result := translator.TranslateWithMap("Bye, {name}!", "de-DE", map[string]string{"name": "Jack"}); result != "Bye, Jack!" {
+		t.Errorf("Unexpected result: %q", result)
+	}
+}
+
+func TestSourceTextTranslator_messageEngine(t *testing.T) {
+	// Prepare test data
+	inner := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"Hello, {name}!": {"fr-FR": "Bonjour {name}"},
+	}, WithMessageEngine(EnginePlaceholders))
+	translator := NewSourceTextTranslator(inner, "en-US")
+	args := map[string]any{"name": "Ann"}
+
+	expected, err := inner.TranslateE("Hello, {name}!", "fr-FR", args)
+	if err != nil || expected != "Bonjour Ann" {
+		t.Fatalf("Unexpected result of inner translator: %q, %v", expected, err)
+	}
+	if result, err := translator.TranslateE("Hello, {name}!", "fr-FR", args); err != nil || result != expected {
+		t.Errorf("Expected %q, got %q & %v", expected, result, err)
+	}
+	if result := translator.Translate("Hello, {name}!", "fr-FR", args); result != expected {
+		t.Errorf("Expected Translate() to return %q, got %q", expected, result)
+	}
+}
+
+func TestSourceTextTranslator_Translate(t *testing.T) {
+	// Prepare test data
+	translator := NewSourceTextTranslator(NewMapTranslator(context.Background(), "de-DE", map[string]map[string]string{
+		"Hello, %s!": {"de-DE": "Hallo, %s!", "uk-UA": "Привіт, %s!"},
+	}), "en-US")
+
+	testCases := []struct {
+		name     string
+		locale   string
+		expected string
+	}{
+		{name: "Translated", locale: "uk-UA", expected: "Привіт, Jack!"},
+		{name: "Variant of translated locale", locale: "de-DE@formal", expected: "Hallo, Jack!"},
+		{name: "Source text instead of default locale of wrapped translator", locale: "fr-FR", expected: "Hello, Jack!"},
+		{name: "Source locale", locale: "en-US", expected: "Hello, Jack!"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := translator.Translate("Hello, %s!", tc.locale, "Jack"); result != tc.expected {
+				t.Errorf("Expected Translate() to return %q, got %q", tc.expected, result)
+			}
+			if result := translator.TranslateNoWarning("Hello, %s!", tc.locale, "Jack"); result != tc.expected {
+				t.Errorf("Expected TranslateNoWarning() to return %q, got %q", tc.expected, result)
+			}
+			if result := translator.Lookup("Hello, %s!", tc.locale); result.Text != strings.TrimSuffix(tc.expected, "Jack!")+"%s!" {
+				t.Errorf("Expected Lookup() to return text of %q, got %q", tc.expected, result.Text)
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+package i18n
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SQLSchema is the schema of the table used by SQLTranslator.
+// Plural forms of a key are rows with plural_form set to a PluralCategory, e.g. "few", that are served
+// under PluralKey(msg_key, plural_form), texts that have no plural forms have an empty plural_form.
+const SQLSchema = `CREATE TABLE i18n_translations (
+	msg_key     VARCHAR(255) NOT NULL,
+	locale      VARCHAR(32)  NOT NULL,
+	plural_form VARCHAR(8)   NOT NULL DEFAULT '',
+	text        TEXT         NOT NULL,
+	updated_at  TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (msg_key, locale, plural_form)
+)`
+
+// SQLUpsertOnDuplicateKey is the upsert statement for MySQL, see WithSQLUpsert
+const SQLUpsertOnDuplicateKey = `INSERT INTO i18n_translations (msg_key, locale, plural_form, text, updated_at)
+	VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP) ON DUPLICATE KEY UPDATE text = VALUES(text), updated_at = CURRENT_TIMESTAMP`
+
+// ErrInvalidTranslation is returned by SQLTranslator.Upsert for translations without key or locale
+// or with an unknown plural form
+var ErrInvalidTranslation = errors.New("invalid translation")
+
+// SQLTranslation is a row of the translations table, see SQLSchema
+type SQLTranslation struct {
+	Key        string
+	Locale     string
+	PluralForm PluralCategory // empty if the text has no plural forms
+	Text       string
+	UpdatedAt  time.Time // set by the database, zero for rows passed to SQLTranslator.Upsert
+}
+
+// key returns key the translation is served under
+func (t SQLTranslation) key() string {
+	if t.PluralForm == "" {
+		return t.Key
+	}
+	return PluralKey(t.Key, t.PluralForm)
+}
+
+// SQLOption configures SQLTranslator
+type SQLOption func(t *SQLTranslator)
+
+// WithSQLPlaceholder sets function returning placeholder of n-th (starting from 1) query argument,
+// default is "?", use DollarPlaceholder for PostgreSQL
+func WithSQLPlaceholder(placeholder func(n int) string) SQLOption {
+	return func(t *SQLTranslator) {
+		t.placeholder = placeholder
+	}
+}
+
+// WithSQLBatchSize sets max number of locales loaded by a query, default is 10, not positive size loads all locales by a single query
+func WithSQLBatchSize(size int) SQLOption {
+	return func(t *SQLTranslator) {
+		t.batchSize = size
+	}
+}
+
+// WithSQLUpsert sets statement inserting or updating a row by msg_key, locale, plural_form & text arguments,
+// default is INSERT ... ON CONFLICT supported by PostgreSQL & SQLite, use SQLUpsertOnDuplicateKey for MySQL
+func WithSQLUpsert(statement string) SQLOption {
+	return func(t *SQLTranslator) {
+		t.upsert = statement
+	}
+}
+
+// WithSQLRefreshInterval sets how often Run refreshes translations, default is 1 minute
+func WithSQLRefreshInterval(interval time.Duration) SQLOption {
+	interval = positiveInterval("WithSQLRefreshInterval", interval)
+	return func(t *SQLTranslator) {
+		t.interval = interval
+	}
+}
+
+// WithSQLRefreshOverlap sets how long before the last loaded updated_at Refresh re-reads rows, default is 1 minute.
+// It must exceed the longest transaction writing translations and the clock skew of database servers,
+// as rows committed later than rows with a newer updated_at are missed otherwise.
+func WithSQLRefreshOverlap(overlap time.Duration) SQLOption {
+	return func(t *SQLTranslator) {
+		t.overlap = overlap
+	}
+}
+
+// WithSQLTranslatorOptions sets options of translators created for loaded translations,
+// a logger set by WithLogger also logs failed refreshes of Run
+func WithSQLTranslatorOptions(options ...MapTranslatorOption) SQLOption {
+	return func(t *SQLTranslator) {
+		t.translatorOptions = options
+	}
+}
+
+// DollarPlaceholder returns PostgreSQL style placeholder, e.g. "$1"
+func DollarPlaceholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+// SQLTranslator serves translations stored in a database, see SQLSchema.
+// Locales are loaded by Preload and kept up to date by Refresh that loads rows updated since the last load
+// (see WithSQLRefreshOverlap), so rows deleted from the table are served until the locale is preloaded again.
+// Loaded rows with texts that fail to compile are not served and reported as errors. It is safe for concurrent use.
+type SQLTranslator struct {
+	swappableTranslator
+	db          *sql.DB
+	placeholder func(n int) string
+	batchSize   int
+	overlap     time.Duration
+	upsert      string
+
+	mutex     sync.Mutex           // serializes loads & upserts
+	locales   []string             // preloaded locales
+	updatedAt map[string]time.Time // max updated_at of loaded rows by locale
+}
+
+var _ Translator = (*SQLTranslator)(nil)
+
+// NewSQLTranslator creates translator for the database, no translations are served until Preload is called
+func NewSQLTranslator(c context.Context, db *sql.DB, defaultLocale string, options ...SQLOption) *SQLTranslator {
+	t := &SQLTranslator{
+		swappableTranslator: swappableTranslator{c: c, defaultLocale: defaultLocale, interval: time.Minute},
+		db:                  db,
+		placeholder:         func(int) string { return "?" },
+		batchSize:           10,
+		overlap:             time.Minute,
+		updatedAt:           make(map[string]time.Time),
+	}
+	for _, option := range options {
+		option(t)
+	}
+	if t.upsert == "" {
+		t.upsert = fmt.Sprintf(`INSERT INTO i18n_translations (msg_key, locale, plural_form, text, updated_at)
+	VALUES (%v, %v, %v, %v, CURRENT_TIMESTAMP) ON CONFLICT (msg_key, locale, plural_form)
+	DO UPDATE SET text = excluded.text, updated_at = excluded.updated_at`,
+			t.placeholder(1), t.placeholder(2), t.placeholder(3), t.placeholder(4))
+	}
+	t.swap(make(map[string]map[string]string), nil)
+	return t
+}
+
+// Preload loads all translations of the locales replacing previously loaded ones,
+// locales are queried in batches (see WithSQLBatchSize)
+func (t *SQLTranslator) Preload(ctx context.Context, locales ...string) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	rows, err := t.query(ctx, locales, false)
+	if err != nil {
+		return err
+	}
+	translations := t.translations()
+	for _, texts := range translations {
+		for _, locale := range locales {
+			delete(texts, locale)
+		}
+	}
+	for _, locale := range locales {
+		delete(t.updatedAt, locale)
+		if !slices.Contains(t.locales, locale) {
+			t.locales = append(t.locales, locale)
+		}
+	}
+	_, err = t.apply(translations, rows)
+	t.swap(translations, nil)
+	return err
+}
+
+// Refresh loads rows of preloaded locales that have been updated since the last load minus the overlap,
+// translations are replaced only if a text has changed
+func (t *SQLTranslator) Refresh(ctx context.Context) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if len(t.locales) == 0 {
+		return nil
+	}
+	rows, err := t.query(ctx, t.locales, true)
+	if err != nil {
+		return err
+	}
+	translations := t.translations()
+	changed, err := t.apply(translations, rows)
+	if changed {
+		t.swap(translations, nil)
+	}
+	return err
+}
+
+// Run refreshes translations until the context is done
+func (t *SQLTranslator) Run(ctx context.Context) {
+	t.run(ctx, func() {
+		if err := t.Refresh(ctx); err != nil {
+			logAttrs(t.c, t.logger(), slog.LevelError, "Failed to refresh translations", slog.Any("error", err))
+		}
+	})
+}
+
+// Upsert inserts or updates translations in a transaction by the statement set with WithSQLUpsert,
+// texts of preloaded locales are served immediately
+func (t *SQLTranslator) Upsert(ctx context.Context, translations ...SQLTranslation) (err error) {
+	validated := make(map[string]map[string]string, len(translations))
+	for _, translation := range translations {
+		if translation.Key == "" || translation.Locale == "" || !validPluralForm(translation.PluralForm) {
+			return fmt.Errorf("%w: key=%v&locale=%v&plural_form=%v", ErrInvalidTranslation, translation.Key, translation.Locale, translation.PluralForm)
+		}
+		if validated[translation.key()] == nil {
+			validated[translation.key()] = make(map[string]string)
+		}
+		validated[translation.key()][translation.Locale] = translation.Text
+	}
+	if err = ValidateTranslations(validated, t.translatorOptions...); err != nil {
+		return err
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	for _, translation := range translations {
+		if _, err = tx.ExecContext(ctx, t.upsert, translation.Key, translation.Locale, string(translation.PluralForm), translation.Text); err != nil {
+			return fmt.Errorf("failed to upsert translation: key=%v&locale=%v: %w", translation.Key, translation.Locale, err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	var loaded []SQLTranslation
+	for _, translation := range translations {
+		if slices.Contains(t.locales, translation.Locale) {
+			loaded = append(loaded, translation)
+		}
+	}
+	served := t.translations()
+	if changed, _ := t.apply(served, loaded); changed { // texts are validated above
+		t.swap(served, nil)
+	}
+	return nil
+}
+
+// query selects rows of the locales, incremental query selects rows updated since the last load of a batch
+func (t *SQLTranslator) query(ctx context.Context, locales []string, incremental bool) (rows []SQLTranslation, err error) {
+	batchSize := t.batchSize
+	if batchSize <= 0 {
+		batchSize = len(locales)
+	}
+	for start := 0; start < len(locales); start += batchSize {
+		batch := locales[start:min(start+batchSize, len(locales))]
+		var query strings.Builder
+		args := make([]any, 0, len(batch)+1)
+		query.WriteString("SELECT msg_key, locale, plural_form, text, updated_at FROM i18n_translations WHERE ")
+		if updatedSince := t.updatedSince(batch); incremental && !updatedSince.IsZero() {
+			args = append(args, updatedSince)
+			query.WriteString("updated_at >= " + t.placeholder(len(args)) + " AND ")
+		}
+		query.WriteString("locale IN (")
+		for i, locale := range batch {
+			if i > 0 {
+				query.WriteString(", ")
+			}
+			args = append(args, locale)
+			query.WriteString(t.placeholder(len(args)))
+		}
+		query.WriteString(")")
+		if rows, err = t.queryRows(ctx, rows, query.String(), args); err != nil {
+			return nil, fmt.Errorf("failed to load translations: locales=%v: %w", batch, err)
+		}
+	}
+	return rows, nil
+}
+
+func (t *SQLTranslator) queryRows(ctx context.Context, rows []SQLTranslation, query string, args []any) (_ []SQLTranslation, err error) {
+	result, err := t.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, result.Close())
+	}()
+	for result.Next() {
+		var row SQLTranslation
+		var pluralForm string
+		if err = result.Scan(&row.Key, &row.Locale, &pluralForm, &row.Text, &row.UpdatedAt); err != nil {
+			return nil, err
+		}
+		row.PluralForm = PluralCategory(pluralForm)
+		rows = append(rows, row)
+	}
+	return rows, result.Err()
+}
+
+// updatedSince returns time rows of the locales are refreshed since: the overlap before the earliest of their max updated_at,
+// or the zero time if a locale has no rows loaded yet
+func (t *SQLTranslator) updatedSince(locales []string) (since time.Time) {
+	for _, locale := range locales {
+		updatedAt, ok := t.updatedAt[locale]
+		if !ok {
+			return time.Time{}
+		}
+		if since.IsZero() || updatedAt.Before(since) {
+			since = updatedAt
+		}
+	}
+	return since.Add(-t.overlap)
+}
+
+// translations returns a copy of currently served translations
+func (t *SQLTranslator) translations() map[string]map[string]string {
+	current := t.Translations()
+	translations := make(map[string]map[string]string, len(current))
+	for key, texts := range current {
+		copied := make(map[string]string, len(texts))
+		for locale, text := range texts {
+			copied[locale] = text
+		}
+		translations[key] = copied
+	}
+	return translations
+}
+
+// apply sets texts of the rows in the translations, so rows read again are applied idempotently,
+// and tells whether any text has changed. Rows with texts that fail to compile are skipped and reported.
+func (t *SQLTranslator) apply(translations map[string]map[string]string, rows []SQLTranslation) (changed bool, err error) {
+	validate := messageValidator(t.translatorOptions...)
+	var errs []error
+	for _, row := range rows {
+		if row.UpdatedAt.After(t.updatedAt[row.Locale]) {
+			t.updatedAt[row.Locale] = row.UpdatedAt
+		}
+		key := row.key()
+		if err = validate(key, row.Locale, row.Text); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if translations[key] == nil {
+			translations[key] = make(map[string]string)
+		}
+		if text, ok := translations[key][row.Locale]; !ok || text != row.Text {
+			translations[key][row.Locale] = row.Text
+			changed = true
+		}
+	}
+	return changed, errors.Join(errs...)
+}
+
+func validPluralForm(form PluralCategory) bool {
+	switch form {
+	case "", PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther:
+		return true
+	}
+	return false
+}
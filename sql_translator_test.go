@@ -0,0 +1,468 @@
+package i18n
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSQLDriver is an in-memory driver understanding queries of SQLTranslator
+type fakeSQLDriver struct {
+	mutex     sync.Mutex
+	rows      []SQLTranslation
+	queries   []string
+	err       error
+	beginErr  error
+	commitErr error
+	nullTexts bool      // texts of selected rows are NULL
+	now       time.Time // CURRENT_TIMESTAMP
+}
+
+func (d *fakeSQLDriver) Open(string) (driver.Conn, error) {
+	return fakeSQLConn{d}, nil
+}
+
+func (d *fakeSQLDriver) Connect(context.Context) (driver.Conn, error) {
+	return fakeSQLConn{d}, nil
+}
+
+func (d *fakeSQLDriver) Driver() driver.Driver {
+	return d
+}
+
+func (d *fakeSQLDriver) selects() (n int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for _, query := range d.queries {
+		if strings.HasPrefix(query, "SELECT") {
+			n++
+		}
+	}
+	return n
+}
+
+type fakeSQLConn struct {
+	d *fakeSQLDriver
+}
+
+func (c fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeSQLStmt{d: c.d, query: query}, nil
+}
+
+func (c fakeSQLConn) Close() error {
+	return nil
+}
+
+func (c fakeSQLConn) Begin() (driver.Tx, error) {
+	return c, c.d.beginErr
+}
+
+func (c fakeSQLConn) Commit() error {
+	return c.d.commitErr
+}
+
+func (c fakeSQLConn) Rollback() error {
+	return nil
+}
+
+type fakeSQLStmt struct {
+	d     *fakeSQLDriver
+	query string
+}
+
+func (s fakeSQLStmt) Close() error {
+	return nil
+}
+
+func (s fakeSQLStmt) NumInput() int {
+	return -1
+}
+
+func (s fakeSQLStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mutex.Lock()
+	defer s.d.mutex.Unlock()
+	s.d.queries = append(s.d.queries, s.query)
+	if s.d.err != nil {
+		return nil, s.d.err
+	}
+	if !strings.HasPrefix(s.query, "INSERT") || !strings.Contains(s.query, "CURRENT_TIMESTAMP") {
+		return nil, errors.New("unexpected query: " + s.query)
+	}
+	for i, row := range s.d.rows {
+		if row.Key == args[0] && row.Locale == args[1] && string(row.PluralForm) == args[2] {
+			s.d.rows[i].Text, s.d.rows[i].UpdatedAt = args[3].(string), s.d.now
+			return driver.RowsAffected(1), nil
+		}
+	}
+	s.d.rows = append(s.d.rows, SQLTranslation{
+		Key: args[0].(string), Locale: args[1].(string), PluralForm: PluralCategory(args[2].(string)),
+		Text: args[3].(string), UpdatedAt: s.d.now,
+	})
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeSQLStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mutex.Lock()
+	defer s.d.mutex.Unlock()
+	s.d.queries = append(s.d.queries, s.query)
+	if s.d.err != nil {
+		return nil, s.d.err
+	}
+	var updatedSince time.Time
+	if strings.Contains(s.query, "updated_at >=") {
+		updatedSince, args = args[0].(time.Time), args[1:]
+	}
+	rows := &fakeSQLRows{}
+	for _, row := range s.d.rows {
+		for _, locale := range args {
+			if row.Locale == locale && !row.UpdatedAt.Before(updatedSince) {
+				var text driver.Value = row.Text
+				if s.d.nullTexts {
+					text = nil
+				}
+				rows.values = append(rows.values, []driver.Value{row.Key, row.Locale, string(row.PluralForm), text, row.UpdatedAt})
+			}
+		}
+	}
+	return rows, nil
+}
+
+type fakeSQLRows struct {
+	values [][]driver.Value
+}
+
+func (r *fakeSQLRows) Columns() []string {
+	return []string{"msg_key", "locale", "plural_form", "text", "updated_at"}
+}
+
+func (r *fakeSQLRows) Close() error {
+	return nil
+}
+
+func (r *fakeSQLRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func newFakeSQLTranslator(options ...SQLOption) (*SQLTranslator, *fakeSQLDriver) {
+	updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := &fakeSQLDriver{now: updatedAt.Add(time.Hour)}
+	d.rows = []SQLTranslation{
+		{Key: "title", Locale: "en-US", Text: "Title", UpdatedAt: updatedAt},
+		{Key: "title", Locale: "de-DE", Text: "Titel", UpdatedAt: updatedAt},
+		{Key: "title", Locale: "fr-FR", Text: "Titre", UpdatedAt: updatedAt},
+		{Key: "files", Locale: "en-US", PluralForm: PluralOne, Text: "%v file", UpdatedAt: updatedAt},
+		{Key: "files", Locale: "en-US", PluralForm: PluralOther, Text: "%v files", UpdatedAt: updatedAt},
+	}
+	return NewSQLTranslator(context.Background(), sql.OpenDB(d), "en-US", options...), d
+}
+
+func TestSQLTranslator_Preload(t *testing.T) {
+	// Prepare test data
+	translator, d := newFakeSQLTranslator(WithSQLBatchSize(2))
+	ctx := context.Background()
+
+	if s := translator.Translate("title", "de-DE"); s != "title" {
+		t.Errorf("Expected key before preload, got %q", s)
+	}
+	if err := translator.Preload(ctx, "en-US", "de-DE", "it-IT"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n := d.selects(); n != 2 {
+		t.Errorf("Expected 2 batched queries, got %d", n)
+	}
+
+	testCases := []struct {
+		name     string
+		key      string
+		locale   string
+		args     []any
+		expected string
+	}{
+		{name: "Preloaded", key: "title", locale: "de-DE", expected: "Titel"},
+		{name: "Not preloaded falls back to default", key: "title", locale: "fr-FR", expected: "Title"},
+		{name: "Plural form", key: PluralKey("files", PluralOther), locale: "en-US", args: []any{2}, expected: "2 files"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if s := translator.Translate(tc.key, tc.locale, tc.args...); s != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, s)
+			}
+		})
+	}
+
+	d.err = errors.New("connection refused")
+	if err := translator.Preload(ctx, "fr-FR"); !errors.Is(err, d.err) {
+		t.Errorf("Expected connection error, got %v", err)
+	}
+	d.err = nil
+	d.nullTexts = true
+	if err := translator.Preload(ctx, "fr-FR"); err == nil || !strings.Contains(err.Error(), "NULL") {
+		t.Errorf("Expected scan error, got %v", err)
+	}
+	if s := translator.Translate("title", "de-DE"); s != "Titel" {
+		t.Errorf("Expected translations to be kept after failure, got %q", s)
+	}
+}
+
+func TestWithSQLBatchSize(t *testing.T) {
+	// Prepare test data
+	translator, d := newFakeSQLTranslator(WithSQLBatchSize(0))
+
+	if err := translator.Preload(context.Background(), "en-US", "de-DE", "fr-FR"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n := d.selects(); n != 1 {
+		t.Errorf("Expected all locales to be loaded by a single query, got %d", n)
+	}
+}
+
+func TestSQLTranslator_Refresh(t *testing.T) {
+	// Prepare test data
+	translator, d := newFakeSQLTranslator()
+	ctx := context.Background()
+	if err := translator.Refresh(ctx); err != nil || len(d.queries) != 0 {
+		t.Errorf("Expected no queries without preloaded locales, got %v & %v", d.queries, err)
+	}
+	if err := translator.Preload(ctx, "en-US", "de-DE"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	d.rows = append(d.rows,
+		SQLTranslation{Key: "cta", Locale: "de-DE", Text: "Registrieren", UpdatedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		SQLTranslation{Key: "cta", Locale: "fr-FR", Text: "S'inscrire", UpdatedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+	)
+
+	if err := translator.Refresh(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s := translator.Translate("cta", "de-DE"); s != "Registrieren" {
+		t.Errorf("Expected refreshed translation, got %q", s)
+	}
+	if _, ok := translator.Translations()["cta"]["fr-FR"]; ok {
+		t.Error("Expected rows of not preloaded locales to be skipped")
+	}
+	if query := d.queries[len(d.queries)-1]; !strings.Contains(query, "updated_at >= ?") {
+		t.Errorf("Expected incremental query, got %q", query)
+	}
+
+	// Rows committed late with an older updated_at are read within the overlap & rows read again change nothing
+	served := translator.current.Load()
+	d.rows = append(d.rows, SQLTranslation{Key: "late", Locale: "de-DE", Text: "Spät", UpdatedAt: time.Date(2024, 1, 31, 23, 59, 30, 0, time.UTC)})
+	if err := translator.Refresh(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s := translator.Translate("late", "de-DE"); s != "Spät" {
+		t.Errorf("Expected row committed late to be refreshed, got %q", s)
+	}
+	if translator.current.Load() == served {
+		t.Error("Expected translator to be replaced for changed texts")
+	}
+	served = translator.current.Load()
+	if err := translator.Refresh(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if translator.current.Load() != served {
+		t.Error("Expected translator to be kept if no text has changed")
+	}
+}
+
+func TestSQLTranslator_Refresh_perLocale(t *testing.T) {
+	// Prepare test data
+	translator, d := newFakeSQLTranslator()
+	ctx := context.Background()
+	if err := translator.Preload(ctx, "en-US"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	d.rows[0].Text, d.rows[0].UpdatedAt = "New title", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	d.rows[1].UpdatedAt = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// Preloading a locale with newer rows must not skip updates of already preloaded locales
+	if err := translator.Preload(ctx, "de-DE"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := translator.Refresh(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s := translator.Translate("title", "en-US"); s != "New title" {
+		t.Errorf("Expected refreshed translation, got %q", s)
+	}
+}
+
+func TestSQLTranslator_invalidRows(t *testing.T) {
+	// Prepare test data
+	translator, d := newFakeSQLTranslator(WithSQLRefreshOverlap(0))
+	ctx := context.Background()
+	d.rows[1].Text = "Hi {{.Name | nope}}"
+
+	if err := translator.Preload(ctx, "en-US", "de-DE"); !errors.Is(err, ErrTemplate) {
+		t.Errorf("Expected template error, got %v", err)
+	}
+	if s := translator.Translate("title", "de-DE"); s != "Title" {
+		t.Errorf("Expected invalid row to be not served, got %q", s)
+	}
+	if s := translator.Translate("title", "en-US"); s != "Title" {
+		t.Errorf("Expected valid rows to be served, got %q", s)
+	}
+
+	d.rows[1].Text, d.rows[1].UpdatedAt = "Titel", d.now
+	d.rows[0].Text, d.rows[0].UpdatedAt = "{{.Name | nope}}", d.now
+	if err := translator.Refresh(ctx); !errors.Is(err, ErrTemplate) {
+		t.Errorf("Expected template error, got %v", err)
+	}
+	if s := translator.Translate("title", "en-US"); s != "Title" {
+		t.Errorf("Expected previous text of invalid row, got %q", s)
+	}
+	if s := translator.Translate("title", "de-DE"); s != "Titel" {
+		t.Errorf("Expected fixed row to be served, got %q", s)
+	}
+	if query := d.queries[len(d.queries)-1]; !strings.Contains(query, "updated_at >= ?") {
+		t.Errorf("Expected incremental query, got %q", query)
+	}
+}
+
+func TestSQLTranslator_Run(t *testing.T) {
+	// Prepare test data
+	logger := &mockLogger{}
+	translator, d := newFakeSQLTranslator(WithSQLRefreshInterval(time.Millisecond), WithSQLTranslatorOptions(WithLogger(logger)))
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := translator.Preload(ctx, "en-US"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	d.mutex.Lock()
+	d.err = errors.New("connection refused")
+	d.mutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		translator.Run(ctx)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for d.selects() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected translations to be refreshed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+	if !logger.errorCalled {
+		t.Error("Expected failed refresh to be logged by the logger of translator options")
+	}
+}
+
+func TestWithSQLRefreshInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected panic for interval %v", interval)
+				}
+			}()
+			WithSQLRefreshInterval(interval)
+		}()
+	}
+}
+
+func TestSQLTranslator_Upsert(t *testing.T) {
+	// Prepare test data
+	translator, d := newFakeSQLTranslator(WithSQLPlaceholder(DollarPlaceholder))
+	ctx := context.Background()
+	if err := translator.Preload(ctx, "en-US"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	err := translator.Upsert(ctx,
+		SQLTranslation{Key: "title", Locale: "en-US", Text: "New title"},
+		SQLTranslation{Key: "cta", Locale: "en-US", Text: "Sign up"},
+		SQLTranslation{Key: "cta", Locale: "de-DE", Text: "Registrieren"},
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(d.rows) != 7 {
+		t.Errorf("Expected 2 inserted rows, got %v", d.rows)
+	}
+	if s := translator.Translate("title", "en-US"); s != "New title" {
+		t.Errorf("Expected updated translation to be served, got %q", s)
+	}
+	if _, ok := translator.Translations()["cta"]["de-DE"]; ok {
+		t.Error("Expected upserted translation of not preloaded locale to be not served")
+	}
+	if query := d.queries[0]; !strings.Contains(query, "locale IN ($1)") {
+		t.Errorf("Expected dollar placeholders, got %q", query)
+	}
+	if query := d.queries[1]; !strings.Contains(query, "VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP) ON CONFLICT") {
+		t.Errorf("Expected native upsert, got %q", query)
+	}
+	if row := d.rows[0]; row.Text != "New title" || !row.UpdatedAt.Equal(d.now) {
+		t.Errorf("Expected row updated at the database time, got %+v", row)
+	}
+
+	for _, invalid := range []SQLTranslation{
+		{Locale: "en-US", Text: "No key"},
+		{Key: "files", Locale: "en-US", PluralForm: "several", Text: "Unknown plural form"},
+		{Key: "broken", Locale: "en-US", Text: "{{.Name | unknown}}"},
+	} {
+		if err := translator.Upsert(ctx, invalid); err == nil {
+			t.Errorf("Expected error for %+v", invalid)
+		}
+	}
+	if len(d.rows) != 7 {
+		t.Errorf("Expected invalid translations to be not stored, got %v", d.rows)
+	}
+
+	// Failures of the database
+	translation := SQLTranslation{Key: "title", Locale: "en-US", Text: "Failed title"}
+	for name, fail := range map[string]func(err error){
+		"begin":  func(err error) { d.beginErr = err },
+		"exec":   func(err error) { d.err = err },
+		"commit": func(err error) { d.commitErr = err },
+	} {
+		failure := errors.New(name + " failed")
+		fail(failure)
+		if err = translator.Upsert(ctx, translation); !errors.Is(err, failure) {
+			t.Errorf("Expected %v, got %v", failure, err)
+		}
+		fail(nil)
+	}
+	if s := translator.Translate("title", "en-US"); s != "New title" {
+		t.Errorf("Expected failed upserts to be not served, got %q", s)
+	}
+
+	// Texts are validated by the engine of served translators
+	translator, d = newFakeSQLTranslator(WithSQLTranslatorOptions(WithMessageEngine(EnginePlaceholders)))
+	if err = translator.Upsert(ctx, SQLTranslation{Key: "hello", Locale: "en-US", Text: "Hello, {name, select}!"}); err == nil {
+		t.Error("Expected error for malformed placeholder")
+	}
+	if len(d.queries) != 0 {
+		t.Errorf("Expected invalid translation to be not stored, got %v", d.queries)
+	}
+}
+
+func TestSQLTranslator_Upsert_statement(t *testing.T) {
+	// Prepare test data
+	translator, d := newFakeSQLTranslator(WithSQLUpsert(SQLUpsertOnDuplicateKey))
+	ctx := context.Background()
+
+	if err := translator.Upsert(ctx, SQLTranslation{Key: "title", Locale: "en-US", Text: "New title"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if query := d.queries[0]; query != SQLUpsertOnDuplicateKey {
+		t.Errorf("Expected MySQL upsert, got %q", query)
+	}
+	if len(d.rows) != 5 || d.rows[0].Text != "New title" {
+		t.Errorf("Expected existing row to be updated, got %v", d.rows)
+	}
+}
@@ -1,6 +1,9 @@
 package i18n
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var _ LocalesProvider = (*supported)(nil)
 
@@ -25,3 +28,37 @@ func (s supported) SupportedLocales() []Locale {
 	copy(locales, s.locales)
 	return locales
 }
+
+// ResolveLocale returns the first of candidate codes supported by the provider or the default locale.
+// Codes like "en_us" are normalized and a code with unsupported region falls back to its language, e.g. "fr-CA" to "fr-FR".
+func ResolveLocale(provider LocalesProvider, defaultLocale Locale, codes ...string) Locale {
+	if locale, ok := resolveLocale(provider, codes); ok {
+		return locale
+	}
+	return defaultLocale
+}
+
+func resolveLocale(provider LocalesProvider, codes []string) (Locale, bool) {
+	for _, code := range codes {
+		code = normalizeLocaleCode(code)
+		if locale, err := provider.GetLocaleByCode5(code); err == nil {
+			return locale, true
+		}
+		if len(code) > 2 {
+			if locale, err := provider.GetLocaleByCode5(code[:2]); err == nil {
+				return locale, true
+			}
+		}
+	}
+	return LocaleUndefined, false
+}
+
+// normalizeLocaleCode converts codes like "en_us" to "en-US"
+func normalizeLocaleCode(code string) string {
+	code = strings.ReplaceAll(strings.TrimSpace(code), "_", "-")
+	lang, region, found := strings.Cut(code, "-")
+	if !found {
+		return strings.ToLower(lang)
+	}
+	return strings.ToLower(lang) + "-" + strings.ToUpper(region)
+}
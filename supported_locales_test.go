@@ -142,3 +142,29 @@ func TestSupported_SupportedLocales(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveLocale(t *testing.T) {
+	provider := supported{locales: []Locale{LocaleEnUS, LocaleFrFR, LocaleUkUA}}
+
+	testCases := []struct {
+		name     string
+		codes    []string
+		expected string
+	}{
+		{name: "No codes", codes: nil, expected: LocaleCodeEnUS},
+		{name: "Exact", codes: []string{"uk-UA"}, expected: LocaleCodeUkUA},
+		{name: "Normalized", codes: []string{"fr_fr"}, expected: LocaleCodeFrFR},
+		{name: "Language only", codes: []string{"uk"}, expected: LocaleCodeUkUA},
+		{name: "Other region", codes: []string{"fr-CA"}, expected: LocaleCodeFrFR},
+		{name: "First supported", codes: []string{"de-DE", "", "uk-UA", "fr-FR"}, expected: LocaleCodeUkUA},
+		{name: "Unsupported", codes: []string{"de-DE"}, expected: LocaleCodeEnUS},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if locale := ResolveLocale(provider, LocaleEnUS, tc.codes...); locale.Code5 != tc.expected {
+				t.Errorf("Expected ResolveLocale(%v) to return %q, got %q", tc.codes, tc.expected, locale.Code5)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// swappableTranslator serves translations replaced atomically so readers are never blocked.
+// It's embedded by translators reloading translations, e.g. ReloadingTranslator, SQLTranslator & RemoteTranslator.
+type swappableTranslator struct {
+	c                 context.Context
+	defaultLocale     string
+	interval          time.Duration // how often run calls its function
+	translatorOptions []MapTranslatorOption
+	current           atomic.Pointer[loadedTranslations]
+}
+
+type loadedTranslations struct {
+	translations map[string]map[string]string
+	translator   Translator
+}
+
+// positiveInterval returns the interval or panics if it's not positive as time.NewTicker would do in Run
+func positiveInterval(option string, interval time.Duration) time.Duration {
+	if interval <= 0 {
+		panic(fmt.Sprintf("i18n: %v requires a positive interval, got %v", option, interval))
+	}
+	return interval
+}
+
+// newTranslator creates translator for loaded translations
+func (t *swappableTranslator) newTranslator(translations map[string]map[string]string) Translator {
+	return NewMapTranslator(t.c, t.defaultLocale, translations, t.translatorOptions...)
+}
+
+// swap starts serving the translations with the translator, nil translator means the one created by newTranslator
+func (t *swappableTranslator) swap(translations map[string]map[string]string, translator Translator) {
+	if translator == nil {
+		translator = t.newTranslator(translations)
+	}
+	t.current.Store(&loadedTranslations{translations: translations, translator: translator})
+}
+
+// run calls f every interval until the context is done
+func (t *swappableTranslator) run(ctx context.Context, f func()) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			f()
+		}
+	}
+}
+
+// Translations returns currently served translations that must not be modified
+func (t *swappableTranslator) Translations() map[string]map[string]string {
+	return t.current.Load().translations
+}
+
+// logger returns logger set by WithLogger in translator options, nil means the package one
+func (t *swappableTranslator) logger() Logger {
+	return optionsLogger(t.translatorOptions)
+}
+
+func (t *swappableTranslator) translator() Translator {
+	return t.current.Load().translator
+}
+
+func (t *swappableTranslator) Lookup(key, locale string) LookupResult {
+	return t.translator().Lookup(key, locale)
+}
+
+func (t *swappableTranslator) Translate(key, locale string, args ...any) string {
+	return t.translator().Translate(key, locale, args...)
+}
+
+func (t *swappableTranslator) TranslateNoWarning(key, locale string, args ...any) string {
+	return t.translator().TranslateNoWarning(key, locale, args...)
+}
+
+func (t *swappableTranslator) TranslateWithMap(key, locale string, args map[string]string) string {
+	return t.translator().TranslateWithMap(key, locale, args)
+}
+
+func (t *swappableTranslator) TranslateE(key, locale string, args ...any) (string, error) {
+	return t.translator().TranslateE(key, locale, args...)
+}
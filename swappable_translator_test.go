@@ -0,0 +1,52 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPositiveInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		t.Run(interval.String(), func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected panic for interval %v", interval)
+				}
+			}()
+			WithReloadInterval(interval)
+		})
+	}
+	if interval := positiveInterval("test", time.Second); interval != time.Second {
+		t.Errorf("Expected interval to be returned, got %v", interval)
+	}
+}
+
+func TestSwappableTranslator(t *testing.T) {
+	// Prepare test data
+	translator := &swappableTranslator{c: context.Background(), defaultLocale: "en-US", interval: time.Millisecond}
+	translator.swap(map[string]map[string]string{"hello": {"en-US": "Hello"}}, nil)
+	if s := translator.Translate("hello", "de-DE"); s != "Hello" {
+		t.Errorf("Expected translation of default locale, got %q", s)
+	}
+
+	translator.swap(map[string]map[string]string{"hello": {"en-US": "Hi"}}, keysTranslator{})
+	if s := translator.Translate("hello", "en-US"); s != "hello" {
+		t.Errorf("Expected given translator to be served, got %q", s)
+	}
+	if s := translator.Translations()["hello"]["en-US"]; s != "Hi" {
+		t.Errorf("Expected swapped translations, got %q", s)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := make(chan struct{})
+	go translator.run(ctx, func() {
+		select {
+		case calls <- struct{}{}:
+		case <-ctx.Done():
+		}
+	})
+	<-calls
+	<-calls
+	cancel()
+}
@@ -0,0 +1,86 @@
+package i18n
+
+import (
+	"fmt"
+	"html/template"
+	"time"
+)
+
+// TemplateFuncs returns functions for server-rendered templates bound to the translator's locale:
+//
+//	{{ t "welcome" .Name }}            - Translate
+//	{{ tn "files" .Count }}            - plural form, see TranslatePlural
+//	{{ tmap "greeting" "name" .Name }} - TranslateWithMap with key/value pairs
+//	{{ number .Total "currency" }}     - FormatNumber, style is optional
+//	{{ date .When }}, {{ time .When }} - FormatDate & FormatTime, style is optional
+//	{{ dir }}, {{ lang }}              - "rtl" or "ltr" and the locale code for HTML attributes
+//
+// Templates must know functions at parse time, so a base template can be parsed with TemplateFuncs(nil).
+// The result can be converted to text/template.FuncMap.
+func TemplateFuncs(t SingleLocaleTranslator) template.FuncMap {
+	locale := func() string {
+		return t.Locale().Code5
+	}
+	return template.FuncMap{
+		"t": func(key string, args ...any) string {
+			return t.Translate(key, args...)
+		},
+		"tn": func(key string, n any, args ...any) string {
+			return TranslatePluralSingle(t, key, n, args...)
+		},
+		"tmap": func(key string, pairs ...any) (string, error) {
+			if len(pairs)%2 != 0 {
+				return "", fmt.Errorf("%w: tmap expects key/value pairs, got %d values", ErrBadArgs, len(pairs))
+			}
+			args := make(map[string]string, len(pairs)/2)
+			for i := 0; i < len(pairs); i += 2 {
+				args[fmt.Sprint(pairs[i])] = fmt.Sprint(pairs[i+1])
+			}
+			return t.TranslateWithMap(key, args), nil
+		},
+		"number": func(v any, style ...string) (string, error) {
+			return FormatNumber(locale(), v, firstOrEmpty(style))
+		},
+		"date": func(v time.Time, style ...string) (string, error) {
+			return FormatDate(locale(), v, firstOrEmpty(style))
+		},
+		"time": func(v time.Time, style ...string) (string, error) {
+			return FormatTime(locale(), v, firstOrEmpty(style))
+		},
+		"dir": func() string {
+			if t.Locale().IsRtl {
+				return "rtl"
+			}
+			return "ltr"
+		},
+		"lang": locale,
+	}
+}
+
+// messageFuncs returns functions available to template messages of the locale
+func messageFuncs(t Translator, locale string) map[string]any {
+	return map[string]any{
+		"t": func(key string, args ...any) string {
+			return t.Translate(key, locale, args...)
+		},
+		"tn": func(key string, n any, args ...any) string {
+			return TranslatePlural(t, key, locale, n, args...)
+		},
+		"number": func(v any, style ...string) (string, error) {
+			return FormatNumber(locale, v, firstOrEmpty(style))
+		},
+		"date": func(v time.Time, style ...string) (string, error) {
+			return FormatDate(locale, v, firstOrEmpty(style))
+		},
+		"time": func(v time.Time, style ...string) (string, error) {
+			return FormatTime(locale, v, firstOrEmpty(style))
+		},
+	}
+}
+
+func firstOrEmpty(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,80 @@
+package i18n
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"html/template"
+	"testing"
+	"time"
+)
+
+func TestMessageFuncs(t *testing.T) {
+	translator := mockTranslator{translations: map[string]map[string]string{
+		"title":       {"de-DE": "Titel"},
+		"files_other": {"de-DE": "Dateien"},
+	}}
+	funcs := messageFuncs(translator, "de-DE")
+
+	if s := funcs["t"].(func(string, ...any) string)("title"); s != "Titel" {
+		t.Errorf("Expected t to translate, got %q", s)
+	}
+	if s := funcs["tn"].(func(string, any, ...any) string)("files", 5, "x"); s != "Dateien x" {
+		t.Errorf("Expected tn to translate plural form, got %q", s)
+	}
+	if s, err := funcs["number"].(func(any, ...string) (string, error))(1234.5); err != nil || s != "1.234,5" {
+		t.Errorf("Expected number to format for locale, got %q, %v", s, err)
+	}
+	when := time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC)
+	if s, err := funcs["date"].(func(time.Time, ...string) (string, error))(when, "iso"); err != nil || s != "2024-05-01" {
+		t.Errorf("Expected date to format with style, got %q, %v", s, err)
+	}
+	if s, err := funcs["time"].(func(time.Time, ...string) (string, error))(when); err != nil || s != "08:00" {
+		t.Errorf("Expected time to format for locale, got %q, %v", s, err)
+	}
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	translator := NewMapTranslator(context.Background(), "en-US", map[string]map[string]string{
+		"welcome":     {"fa-IR": "خوش آمدید %s"},
+		"greeting":    {"fa-IR": "سلام {name}"},
+		"items_one":   {"fa-IR": "%d مورد"},
+		"items_other": {"fa-IR": "%d موارد"},
+	})
+	tmpl := template.Must(template.New("page").Funcs(TemplateFuncs(nil)).Parse(
+		`<html lang="{{lang}}" dir="{{dir}}">{{t "welcome" .Name}}|{{tmap "greeting" "name" .Name}}|{{tn "items" .Count}}|{{number .Total}}</html>`,
+	))
+	tmpl.Funcs(TemplateFuncs(NewSingleMapTranslator(LocaleFaIR, translator)))
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, map[string]any{"Name": "Ali", "Count": 2, "Total": 1500.5}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `<html lang="fa-IR" dir="rtl">خوش آمدید Ali|سلام Ali|2 موارد|1,500.5</html>`
+	if result := buffer.String(); result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestTemplateFuncs_DateAndTime(t *testing.T) {
+	// Prepare test data
+	tmpl := template.Must(template.New("page").Funcs(TemplateFuncs(nil)).Parse(
+		`<p dir="{{dir}}">{{date .When}} {{time .When "medium"}}</p>`,
+	))
+	tmpl.Funcs(TemplateFuncs(NewSingleMapTranslator(LocaleEnUS, mockTranslator{})))
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, map[string]any{"When": time.Date(2024, time.May, 1, 8, 0, 0, 0, time.UTC)}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := `<p dir="ltr">5/1/2024 8:00:00 AM</p>`; buffer.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buffer.String())
+	}
+}
+
+func TestTemplateFuncs_TmapOddArgs(t *testing.T) {
+	funcs := TemplateFuncs(NewSingleMapTranslator(LocaleEnUS, mockTranslator{}))
+	if _, err := funcs["tmap"].(func(string, ...any) (string, error))("greeting", "name"); !errors.Is(err, ErrBadArgs) {
+		t.Errorf("Expected ErrBadArgs, got %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+package i18n
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+type tenantContextKey struct{}
+
+// WithTenant returns a copy of the context carrying ID of a tenant, see TenantTranslators
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// TenantFromContext returns tenant ID stored in the context by WithTenant or an empty string
+func TenantFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	tenantID, _ := ctx.Value(tenantContextKey{}).(string)
+	return tenantID
+}
+
+// TenantLoader loads translation overrides of a tenant, nil translations mean the tenant has no overrides
+type TenantLoader func(ctx context.Context, tenantID string) (map[string]map[string]string, error)
+
+// TenantOption configures TenantTranslators
+type TenantOption func(t *TenantTranslators)
+
+// WithTenantLoader sets loader of overrides for tenants that have not been loaded with LoadTenant
+func WithTenantLoader(loader TenantLoader) TenantOption {
+	return func(t *TenantTranslators) {
+		t.loader = loader
+	}
+}
+
+// WithTenantTranslatorOptions sets options of translators created for overrides of tenants,
+// a logger set by WithLogger also logs failed loads
+func WithTenantTranslatorOptions(options ...MapTranslatorOption) TenantOption {
+	return func(t *TenantTranslators) {
+		t.translatorOptions = options
+	}
+}
+
+// WithTenantRetryDelay sets how long the base translator is served to a tenant after its TenantLoader failed
+// before loading is retried, default is 10 seconds
+func WithTenantRetryDelay(delay time.Duration) TenantOption {
+	return func(t *TenantTranslators) {
+		t.retryDelay = delay
+	}
+}
+
+// TenantTranslators provides translators with per-tenant overrides over base translations, e.g. for white-label bots.
+// Translators are cached per tenant, tenants can be loaded & unloaded at runtime. It is safe for concurrent use.
+type TenantTranslators struct {
+	c                 context.Context
+	base              Translator
+	defaultLocale     string
+	loader            TenantLoader
+	translatorOptions []MapTranslatorOption
+	retryDelay        time.Duration
+	now               func() time.Time
+
+	translators sync.Map // tenant ID => Translator
+
+	mutex    sync.Mutex             // guards loads & failures
+	loads    map[string]*tenantLoad // loads in progress by tenant ID
+	failures map[string]time.Time   // time to retry failed loads by tenant ID
+}
+
+// tenantLoad is a call of TenantLoader shared by concurrent requests of a tenant
+type tenantLoad struct {
+	done       chan struct{}
+	translator Translator // nil if loading failed
+	discarded  bool       // tenant has been loaded or unloaded explicitly while loading
+}
+
+// NewTenantTranslators creates TenantTranslators
+func NewTenantTranslators(c context.Context, base Translator, defaultLocale string, options ...TenantOption) *TenantTranslators {
+	t := &TenantTranslators{
+		c:             c,
+		base:          base,
+		defaultLocale: defaultLocale,
+		retryDelay:    10 * time.Second,
+		now:           time.Now,
+		loads:         make(map[string]*tenantLoad),
+		failures:      make(map[string]time.Time),
+	}
+	for _, option := range options {
+		option(t)
+	}
+	return t
+}
+
+// Translator returns translator for the tenant of the context (see WithTenant) or the base one if there is no tenant.
+// Overrides of tenants that have not been loaded are loaded by TenantLoader if any. Concurrent requests of a tenant
+// share a single load that doesn't block other tenants. If loading fails the base translator is returned
+// until the retry delay passes, see WithTenantRetryDelay.
+func (t *TenantTranslators) Translator(ctx context.Context) Translator {
+	tenantID := TenantFromContext(ctx)
+	if tenantID == "" {
+		return t.base
+	}
+	if translator, ok := t.translators.Load(tenantID); ok {
+		return translator.(Translator)
+	}
+	if t.loader == nil {
+		return t.base
+	}
+	return t.loaded(ctx, tenantID)
+}
+
+// loaded returns translator of a tenant that has not been cached yet by starting or waiting for its load
+func (t *TenantTranslators) loaded(ctx context.Context, tenantID string) Translator {
+	t.mutex.Lock()
+	if translator, ok := t.translators.Load(tenantID); ok { // cached by a concurrent call
+		t.mutex.Unlock()
+		return translator.(Translator)
+	}
+	if retryAt, failed := t.failures[tenantID]; failed && t.now().Before(retryAt) {
+		t.mutex.Unlock()
+		return t.base
+	}
+	load, loading := t.loads[tenantID]
+	if !loading {
+		load = &tenantLoad{done: make(chan struct{})}
+		t.loads[tenantID] = load
+	}
+	t.mutex.Unlock()
+	if !loading {
+		// Cancellation of the request that started loading must not fail it for other requests
+		t.load(context.WithoutCancel(ctx), tenantID, load)
+	}
+	select {
+	case <-load.done:
+		if load.translator != nil {
+			return load.translator
+		}
+		if translator, ok := t.translators.Load(tenantID); ok { // loaded by LoadTenant while loading
+			return translator.(Translator)
+		}
+	case <-ctx.Done():
+	}
+	return t.base
+}
+
+func (t *TenantTranslators) load(ctx context.Context, tenantID string, load *tenantLoad) {
+	defer close(load.done)
+	overrides, err := t.loader(ctx, tenantID)
+	if err != nil {
+		logAttrs(ctx, optionsLogger(t.translatorOptions), slog.LevelError, "Failed to load tenant translations",
+			slog.String("tenant", tenantID), slog.Any("error", err))
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.loads[tenantID] == load {
+		delete(t.loads, tenantID)
+	}
+	if load.discarded {
+		return
+	}
+	if err != nil {
+		t.failures[tenantID] = t.now().Add(t.retryDelay)
+		return
+	}
+	delete(t.failures, tenantID)
+	load.translator = t.store(tenantID, overrides)
+}
+
+func (t *TenantTranslators) store(tenantID string, overrides map[string]map[string]string) Translator {
+	translator := t.base
+	if len(overrides) > 0 {
+		translator = NewLayeredTranslator(t.c, []Layer{
+			{Name: "tenant:" + tenantID, Translator: NewMapTranslator(t.c, t.defaultLocale, overrides, t.translatorOptions...)},
+			{Name: "base", Translator: t.base},
+		}, WithLayeredLogger(optionsLogger(t.translatorOptions)))
+	}
+	t.translators.Store(tenantID, translator)
+	return translator
+}
+
+// LoadTenant sets translation overrides of a tenant replacing previously loaded ones
+func (t *TenantTranslators) LoadTenant(tenantID string, overrides map[string]map[string]string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.discardLoad(tenantID)
+	t.store(tenantID, overrides)
+}
+
+// UnloadTenant removes cached translator of a tenant, it will be loaded again on next use if there is a TenantLoader
+func (t *TenantTranslators) UnloadTenant(tenantID string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.discardLoad(tenantID)
+	t.translators.Delete(tenantID)
+}
+
+// discardLoad makes result of a load in progress ignored and forgets a failed load, must be called with the mutex locked
+func (t *TenantTranslators) discardLoad(tenantID string) {
+	if load, ok := t.loads[tenantID]; ok {
+		load.discarded = true
+		delete(t.loads, tenantID)
+	}
+	delete(t.failures, tenantID)
+}
+
+// Tenants returns IDs of loaded tenants
+func (t *TenantTranslators) Tenants() (tenantIDs []string) {
+	t.translators.Range(func(tenantID, _ any) bool {
+		tenantIDs = append(tenantIDs, tenantID.(string))
+		return true
+	})
+	sort.Strings(tenantIDs)
+	return tenantIDs
+}
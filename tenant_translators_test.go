@@ -0,0 +1,252 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTenantFromContext(t *testing.T) {
+	if tenantID := TenantFromContext(context.Background()); tenantID != "" {
+		t.Errorf("Expected no tenant, got %q", tenantID)
+	}
+	if tenantID := TenantFromContext(WithTenant(context.Background(), "acme")); tenantID != "acme" {
+		t.Errorf("Expected acme, got %q", tenantID)
+	}
+	var ctx context.Context
+	if tenantID := TenantFromContext(ctx); tenantID != "" {
+		t.Errorf("Expected no tenant for nil context, got %q", tenantID)
+	}
+}
+
+func TestTenantTranslators_Translator(t *testing.T) {
+	// Prepare test data
+	ctx := context.Background()
+	base := NewMapTranslator(ctx, "en-US", map[string]map[string]string{
+		"title": {"en-US": "Title", "de-DE": "Titel"},
+		"cta":   {"en-US": "Sign up", "de-DE": "Registrieren"},
+	})
+	var loads atomic.Int32
+	tenants := NewTenantTranslators(ctx, base, "en-US", WithTenantLoader(func(_ context.Context, tenantID string) (map[string]map[string]string, error) {
+		loads.Add(1)
+		switch tenantID {
+		case "acme":
+			return map[string]map[string]string{"title": {"en-US": "Acme"}}, nil
+		case "broken":
+			return nil, errors.New("storage is unavailable")
+		}
+		return nil, nil
+	}))
+	tenants.LoadTenant("globex", map[string]map[string]string{"cta": {"de-DE": "Jetzt bei Globex"}})
+
+	testCases := []struct {
+		name     string
+		tenant   string
+		key      string
+		locale   string
+		expected string
+		source   string
+	}{
+		{name: "No tenant", key: "title", locale: "en-US", expected: "Title"},
+		{name: "Loaded override", tenant: "acme", key: "title", locale: "en-US", expected: "Acme", source: "tenant:acme"},
+		{name: "Base has requested locale", tenant: "acme", key: "title", locale: "de-DE", expected: "Titel", source: "base"},
+		{name: "Falls through to base", tenant: "acme", key: "cta", locale: "en-US", expected: "Sign up", source: "base"},
+		{name: "Override set at runtime", tenant: "globex", key: "cta", locale: "de-DE", expected: "Jetzt bei Globex", source: "tenant:globex"},
+		{name: "Tenant without overrides", tenant: "initech", key: "title", locale: "en-US", expected: "Title"},
+		{name: "Failed to load", tenant: "broken", key: "title", locale: "en-US", expected: "Title"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := ctx
+			if tc.tenant != "" {
+				c = WithTenant(ctx, tc.tenant)
+			}
+			result := tenants.Translator(c).Lookup(tc.key, tc.locale)
+			if result.Text != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result.Text)
+			}
+			if result.Source != tc.source {
+				t.Errorf("Expected source %q, got %q", tc.source, result.Source)
+			}
+		})
+	}
+
+	if expected := []string{"acme", "globex", "initech"}; !reflect.DeepEqual(tenants.Tenants(), expected) {
+		t.Errorf("Expected tenants %v, got %v", expected, tenants.Tenants())
+	}
+
+	// Loaded tenants are cached while failed loads are retried after the delay
+	now := time.Now()
+	tenants.now = func() time.Time {
+		return now
+	}
+	loaded := loads.Load()
+	tenants.Translator(WithTenant(ctx, "acme"))
+	tenants.Translator(WithTenant(ctx, "broken"))
+	if result := tenants.loaded(ctx, "acme").Lookup("title", "en-US"); result.Source != "tenant:acme" {
+		t.Errorf("Expected translator cached by a concurrent call to be reused, got %+v", result)
+	}
+	if n := loads.Load() - loaded; n != 0 {
+		t.Errorf("Expected no loads within the retry delay, got %d", n)
+	}
+	now = now.Add(time.Minute)
+	tenants.Translator(WithTenant(ctx, "broken"))
+	if n := loads.Load() - loaded; n != 1 {
+		t.Errorf("Expected 1 more load after the retry delay, got %d", n)
+	}
+}
+
+func TestTenantTranslators_options(t *testing.T) {
+	// Prepare test data
+	ctx := context.Background()
+	base := NewMapTranslator(ctx, "en-US", map[string]map[string]string{"title": {"en-US": "Title"}})
+	logger := &mockLogger{}
+	var loads atomic.Int32
+	tenants := NewTenantTranslators(ctx, base, "en-US",
+		WithTenantLoader(func(context.Context, string) (map[string]map[string]string, error) {
+			if loads.Add(1) == 1 {
+				return nil, errors.New("storage is unavailable")
+			}
+			return map[string]map[string]string{"title": {"de-DE": "Acme"}}, nil
+		}),
+		WithTenantRetryDelay(time.Hour),
+		WithTenantTranslatorOptions(WithLogger(logger)),
+	)
+	now := time.Now()
+	tenants.now = func() time.Time {
+		return now
+	}
+	tenantCtx := WithTenant(ctx, "acme")
+
+	if s := tenants.Translator(tenantCtx).Translate("title", "en-US"); s != "Title" {
+		t.Errorf("Expected base translation after failed load, got %q", s)
+	}
+	if !logger.errorCalled {
+		t.Error("Expected failed load to be logged by the logger of translator options")
+	}
+	now = now.Add(time.Minute)
+	tenants.Translator(tenantCtx)
+	if n := loads.Load(); n != 1 {
+		t.Errorf("Expected no loads within the retry delay, got %d", n)
+	}
+	now = now.Add(time.Hour)
+	if s := tenants.Translator(tenantCtx).Translate("title", "fr-FR"); s != "Title" {
+		t.Errorf("Expected base translation of the default locale, got %q", s)
+	}
+	if n := loads.Load(); n != 2 {
+		t.Errorf("Expected a load after the retry delay, got %d", n)
+	}
+	logger.warningCalled = false
+	_ = tenants.Translator(tenantCtx).Translate("unknown", "de-DE")
+	if !logger.warningCalled {
+		t.Error("Expected missing key to be logged by the logger of translator options")
+	}
+}
+
+func TestTenantTranslators_UnloadTenant(t *testing.T) {
+	// Prepare test data
+	ctx := context.Background()
+	base := NewMapTranslator(ctx, "en-US", map[string]map[string]string{"title": {"en-US": "Title"}})
+	tenants := NewTenantTranslators(ctx, base, "en-US")
+	tenantCtx := WithTenant(ctx, "acme")
+
+	tenants.LoadTenant("acme", map[string]map[string]string{"title": {"en-US": "Acme"}})
+	if s := tenants.Translator(tenantCtx).Translate("title", "en-US"); s != "Acme" {
+		t.Errorf("Expected override, got %q", s)
+	}
+	tenants.LoadTenant("acme", map[string]map[string]string{"title": {"en-US": "Acme Corp"}})
+	if s := tenants.Translator(tenantCtx).Translate("title", "en-US"); s != "Acme Corp" {
+		t.Errorf("Expected reloaded override, got %q", s)
+	}
+	tenants.UnloadTenant("acme")
+	if s := tenants.Translator(tenantCtx).Translate("title", "en-US"); s != "Title" {
+		t.Errorf("Expected base translation after unload, got %q", s)
+	}
+	if ids := tenants.Tenants(); len(ids) != 0 {
+		t.Errorf("Expected no tenants, got %v", ids)
+	}
+}
+
+func TestTenantTranslators_concurrentLoads(t *testing.T) {
+	// Prepare test data
+	ctx := context.Background()
+	base := NewMapTranslator(ctx, "en-US", map[string]map[string]string{"title": {"en-US": "Title"}})
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var loads atomic.Int32
+	tenants := NewTenantTranslators(ctx, base, "en-US", WithTenantLoader(func(_ context.Context, tenantID string) (map[string]map[string]string, error) {
+		if tenantID == "slow" {
+			if loads.Add(1) == 1 {
+				close(started)
+			}
+			<-release
+		}
+		return map[string]map[string]string{"title": {"en-US": tenantID}}, nil
+	}))
+
+	var wg sync.WaitGroup
+	results := make([]string, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = tenants.Translator(WithTenant(ctx, "slow")).Translate("title", "en-US")
+		}()
+	}
+	<-started
+
+	// A slow load blocks neither other tenants nor loading & unloading
+	if s := tenants.Translator(WithTenant(ctx, "fast")).Translate("title", "en-US"); s != "fast" {
+		t.Errorf("Expected other tenant to be loaded, got %q", s)
+	}
+	tenants.UnloadTenant("fast")
+	canceled, cancel := context.WithCancel(WithTenant(ctx, "slow"))
+	cancel()
+	if s := tenants.Translator(canceled).Translate("title", "en-US"); s != "Title" {
+		t.Errorf("Expected base translation for canceled request, got %q", s)
+	}
+
+	close(release)
+	wg.Wait()
+	if n := loads.Load(); n != 1 {
+		t.Errorf("Expected a single load of the tenant, got %d", n)
+	}
+	for _, s := range results {
+		if s != "slow" {
+			t.Errorf("Expected all requests to get loaded translator, got %v", results)
+			break
+		}
+	}
+}
+
+func TestTenantTranslators_LoadTenant_whileLoading(t *testing.T) {
+	// Prepare test data
+	ctx := context.Background()
+	base := NewMapTranslator(ctx, "en-US", map[string]map[string]string{"title": {"en-US": "Title"}})
+	release := make(chan struct{})
+	started := make(chan struct{})
+	tenants := NewTenantTranslators(ctx, base, "en-US", WithTenantLoader(func(context.Context, string) (map[string]map[string]string, error) {
+		close(started)
+		<-release
+		return map[string]map[string]string{"title": {"en-US": "Stale"}}, nil
+	}))
+
+	result := make(chan string)
+	go func() {
+		result <- tenants.Translator(WithTenant(ctx, "acme")).Translate("title", "en-US")
+	}()
+	<-started
+	tenants.LoadTenant("acme", map[string]map[string]string{"title": {"en-US": "Acme"}})
+	close(release)
+	if s := <-result; s != "Acme" {
+		t.Errorf("Expected explicitly loaded overrides, got %q", s)
+	}
+	if s := tenants.Translator(WithTenant(ctx, "acme")).Translate("title", "en-US"); s != "Acme" {
+		t.Errorf("Expected result of discarded load to be ignored, got %q", s)
+	}
+}
@@ -1,36 +1,163 @@
 package i18n
 
-import "context"
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
 
 // TranslationContext is an i18n context (internationalization)
 type TranslationContext interface {
 	LocalesProvider
+
+	// Locale returns current locale or the default one if locale has not been set
+	Locale() Locale
+
+	// GetTranslator returns translator for the locale of c if it has one (see WithLocale), otherwise for the current locale
 	GetTranslator(c context.Context) Translator
-	SetLocale(code5 string) error
+
+	// SingleLocaleTranslator returns translator to the locale of c or the current one honoring the fallback policy
+	// and formality of c (see WithFormality)
+	SingleLocaleTranslator(c context.Context) SingleLocaleTranslator
+
+	// SetLocale sets current locale and returns the previous one
+	SetLocale(code5 string) (previous Locale, err error)
+}
+
+// FallbackPolicy defines what SingleLocaleTranslator of a TranslationContext does with missing translations
+type FallbackPolicy int
+
+const (
+	// FallbackToDefaultLocale takes missing translations from the default locale
+	FallbackToDefaultLocale FallbackPolicy = iota
+
+	// FallbackNone leaves missing translations to the translator of current locale
+	FallbackNone
+)
+
+// LocaleChangeHook is called by TranslationContext.SetLocale when the locale has been changed
+type LocaleChangeHook = func(ctx context.Context, previous, current Locale)
+
+// TranslationContextOption configures TranslationContext created by NewContext
+type TranslationContextOption func(l10n *translationContext)
+
+// WithTranslatorProvider sets provider of translators, without it translators return keys as is
+func WithTranslatorProvider(translatorProvider TranslatorProvider) TranslationContextOption {
+	return func(l10n *translationContext) {
+		l10n.translatorProvider = translatorProvider
+	}
+}
+
+// WithDefaultLocale sets locale used until SetLocale is called and as a fallback for missing translations
+func WithDefaultLocale(locale Locale) TranslationContextOption {
+	return func(l10n *translationContext) {
+		l10n.defaultLocale = locale
+	}
+}
+
+// WithFallbackPolicy sets fallback policy, default is FallbackToDefaultLocale
+func WithFallbackPolicy(policy FallbackPolicy) TranslationContextOption {
+	return func(l10n *translationContext) {
+		l10n.fallbackPolicy = policy
+	}
+}
+
+// WithLocaleChangeHook adds a hook called when locale is changed
+func WithLocaleChangeHook(hook LocaleChangeHook) TranslationContextOption {
+	return func(l10n *translationContext) {
+		l10n.localeChangeHooks = append(l10n.localeChangeHooks, hook)
+	}
 }
 
-func NewContext(c context.Context, supportedLocales LocalesProvider) TranslationContext {
-	return &translationContext{ctx: c, LocalesProvider: supportedLocales}
+// WithContextLogger sets logger of the TranslationContext overriding the package one set by SetLogger
+func WithContextLogger(logger Logger) TranslationContextOption {
+	return func(l10n *translationContext) {
+		l10n.logger = logger
+	}
+}
+
+// NewContext creates TranslationContext
+func NewContext(c context.Context, supportedLocales LocalesProvider, options ...TranslationContextOption) TranslationContext {
+	l10n := &translationContext{ctx: c, LocalesProvider: supportedLocales}
+	for _, option := range options {
+		option(l10n)
+	}
+	return l10n
 }
 
 type translationContext struct {
 	ctx                context.Context
+	mutex              sync.RWMutex
 	locale             Locale
+	defaultLocale      Locale
+	fallbackPolicy     FallbackPolicy
+	localeChangeHooks  []LocaleChangeHook
 	translatorProvider TranslatorProvider
+	logger             Logger
 	LocalesProvider
 }
 
-func (l10n *translationContext) GetTranslator(_ context.Context) Translator {
-	return l10n.translatorProvider(l10n.locale.Code5)
+func (l10n *translationContext) Locale() Locale {
+	l10n.mutex.RLock()
+	defer l10n.mutex.RUnlock()
+	return l10n.currentLocale()
+}
+
+// currentLocale must be called with the mutex locked
+func (l10n *translationContext) currentLocale() Locale {
+	if l10n.locale.Code5 == "" {
+		return l10n.defaultLocale
+	}
+	return l10n.locale
+}
+
+func (l10n *translationContext) localeOf(c context.Context) Locale {
+	if locale, ok := LocaleFromContext(c); ok {
+		return locale
+	}
+	return l10n.Locale()
+}
+
+func (l10n *translationContext) translator(code5 string) Translator {
+	if l10n.translatorProvider == nil {
+		logf(l10n.ctx, l10n.logger, slog.LevelWarn, "translationContext has no translator provider, keys are returned as is")
+		return keysTranslator{}
+	}
+	return l10n.translatorProvider(code5)
+}
+
+func (l10n *translationContext) GetTranslator(c context.Context) Translator {
+	return l10n.translator(l10n.localeOf(c).Code5)
 }
 
-func (l10n *translationContext) SetLocale(code5 string) error {
+func (l10n *translationContext) SingleLocaleTranslator(c context.Context) SingleLocaleTranslator {
+	locale := l10n.localeOf(c)
+	formality := WithFormalityVariant(FormalityFromContext(c))
+	translator := NewSingleMapTranslator(locale, l10n.translator(locale.Code5), formality)
+	if defaultLocale := l10n.defaultLocale; l10n.fallbackPolicy == FallbackToDefaultLocale &&
+		defaultLocale.Code5 != "" && defaultLocale.Code5 != locale.Code5 {
+		backup := NewSingleMapTranslator(defaultLocale, l10n.translator(defaultLocale.Code5), formality)
+		return NewSingleLocaleTranslatorWithBackup(translator, backup)
+	}
+	return translator
+}
+
+func (l10n *translationContext) SetLocale(code5 string) (previous Locale, err error) {
 	locale, err := l10n.GetLocaleByCode5(code5)
 	if err != nil {
-		errorf(l10n.ctx, "*WebhookContextBase.SetLocate(%v) - %v", code5, err)
-		return err
+		logAttrs(l10n.ctx, l10n.logger, slog.LevelError, "Failed to set locale",
+			slog.String(LogAttrLocale, code5), slog.Any("error", err))
+		return l10n.Locale(), err
 	}
+	l10n.mutex.Lock()
+	previous = l10n.currentLocale()
 	l10n.locale = locale
-	debugf(l10n.ctx, "*WebhookContextBase.SetLocale(%v) => Done", code5)
-	return nil
+	l10n.mutex.Unlock()
+	logAttrs(l10n.ctx, l10n.logger, slog.LevelDebug, "Locale set", slog.String(LogAttrLocale, code5))
+	if previous.Code5 != locale.Code5 {
+		for _, hook := range l10n.localeChangeHooks {
+			hook(l10n.ctx, previous, locale)
+		}
+	}
+	return previous, nil
 }
@@ -3,6 +3,7 @@ package i18n
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 )
 
@@ -23,6 +24,14 @@ func (m contextMockTranslator) TranslateNoWarning(key, locale string, _ ...any)
 	return key + "_" + locale
 }
 
+func (m contextMockTranslator) Lookup(key, locale string) LookupResult {
+	return LookupResult{Key: key, Locale: locale, Text: key + "_" + locale, ServedLocale: locale, Found: true, FallbackPath: []string{locale}}
+}
+
+func (m contextMockTranslator) TranslateE(key, locale string, _ ...any) (string, error) {
+	return key + "_" + locale, nil
+}
+
 // mockLocalesProvider is a simple implementation of the LocalesProvider interface for testing
 type mockLocalesProvider struct {
 	locales       []Locale
@@ -137,7 +146,7 @@ func TestTranslationContext_SetLocale(t *testing.T) {
 			translationCtx := NewContext(ctx, provider).(*translationContext)
 
 			// Test SetLocale
-			err := translationCtx.SetLocale(tc.code5)
+			_, err := translationCtx.SetLocale(tc.code5)
 
 			if tc.expectError {
 				if err == nil {
@@ -156,3 +165,140 @@ func TestTranslationContext_SetLocale(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslationContext_SetLocale_concurrent(t *testing.T) {
+	// Prepare test data
+	locales := []Locale{LocaleEnUS, LocaleFrFR, LocaleDeDE, LocaleRuRU}
+	translationCtx := NewContext(context.Background(), mockLocalesProvider{locales: locales}, WithDefaultLocale(LocaleEnUS))
+
+	// Each locale is set once, so every locale is replaced at most once
+	var wg sync.WaitGroup
+	previous := make([]string, len(locales)-1)
+	for i, locale := range locales[1:] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p, err := translationCtx.SetLocale(locale.Code5)
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			previous[i] = p.Code5
+		}()
+	}
+	wg.Wait()
+	seen := make(map[string]bool, len(previous))
+	for _, code5 := range previous {
+		if seen[code5] {
+			t.Errorf("Locale %v is returned as previous more than once: %v", code5, previous)
+		}
+		seen[code5] = true
+	}
+}
+
+func TestNewContext_WithOptions(t *testing.T) {
+	// Prepare test data
+	ctx := context.Background()
+	locales := []Locale{LocaleEnUS, LocaleFrFR, LocaleDeDE}
+	provider := mockLocalesProvider{locales: locales}
+	translator := NewMapTranslator(ctx, "en-US", map[string]map[string]string{
+		"hello": {"en-US": "Hello", "fr-FR": "Bonjour"},
+		"bye":   {"en-US": "Bye"},
+	})
+
+	type change struct{ previous, current string }
+	var changes []change
+
+	translationCtx := NewContext(ctx, provider,
+		WithTranslatorProvider(func(locale string) Translator {
+			return translator
+		}),
+		WithDefaultLocale(LocaleEnUS),
+		WithLocaleChangeHook(func(_ context.Context, previous, current Locale) {
+			changes = append(changes, change{previous.Code5, current.Code5})
+		}),
+	)
+
+	if locale := translationCtx.Locale(); locale.Code5 != LocaleCodeEnUS {
+		t.Errorf("Expected default locale before SetLocale, got %q", locale.Code5)
+	}
+
+	previous, err := translationCtx.SetLocale(LocaleCodeFrFR)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if previous.Code5 != LocaleCodeEnUS {
+		t.Errorf("Expected previous locale %q, got %q", LocaleCodeEnUS, previous.Code5)
+	}
+	if locale := translationCtx.Locale(); locale.Code5 != LocaleCodeFrFR {
+		t.Errorf("Expected locale %q, got %q", LocaleCodeFrFR, locale.Code5)
+	}
+
+	// Setting the same locale again does not emit a change
+	if previous, _ = translationCtx.SetLocale(LocaleCodeFrFR); previous.Code5 != LocaleCodeFrFR {
+		t.Errorf("Expected previous locale %q, got %q", LocaleCodeFrFR, previous.Code5)
+	}
+	if len(changes) != 1 || changes[0] != (change{LocaleCodeEnUS, LocaleCodeFrFR}) {
+		t.Errorf("Expected a single locale change en-US => fr-FR, got %v", changes)
+	}
+
+	// Failed SetLocale keeps the current locale
+	if previous, err = translationCtx.SetLocale("xx-XX"); err == nil || previous.Code5 != LocaleCodeFrFR {
+		t.Errorf("Expected error and current locale, got %q, %v", previous.Code5, err)
+	}
+
+	single := translationCtx.SingleLocaleTranslator(ctx)
+	if single.Locale().Code5 != LocaleCodeFrFR {
+		t.Errorf("Expected translator for %q, got %q", LocaleCodeFrFR, single.Locale().Code5)
+	}
+	if s := single.Translate("hello"); s != "Bonjour" {
+		t.Errorf("Expected %q, got %q", "Bonjour", s)
+	}
+	if s, err := single.TranslateE("bye"); err != nil || s != "Bye" {
+		t.Errorf("Expected fallback to default locale, got %q, %v", s, err)
+	}
+
+	// Locale of a request context takes precedence
+	if single = translationCtx.SingleLocaleTranslator(WithLocale(ctx, LocaleDeDE)); single.Locale().Code5 != LocaleCodeDeDE {
+		t.Errorf("Expected translator for %q, got %q", LocaleCodeDeDE, single.Locale().Code5)
+	}
+}
+
+func TestTranslationContext_FallbackNone(t *testing.T) {
+	ctx := context.Background()
+	provider := mockLocalesProvider{locales: []Locale{LocaleEnUS, LocaleFrFR}}
+	translationCtx := NewContext(ctx, provider,
+		WithTranslatorProvider(func(locale string) Translator {
+			return contextMockTranslator{locale: locale}
+		}),
+		WithDefaultLocale(LocaleEnUS),
+		WithFallbackPolicy(FallbackNone),
+	)
+	if _, err := translationCtx.SetLocale(LocaleCodeFrFR); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, isBackup := translationCtx.SingleLocaleTranslator(ctx).(SingleLocaleTranslatorWithBackup); isBackup {
+		t.Error("Expected no backup translator with FallbackNone policy")
+	}
+}
+
+func TestTranslationContext_NoTranslatorProvider(t *testing.T) {
+	// A context created by the public constructor without options must not panic
+	translationCtx := NewContext(context.Background(), mockLocalesProvider{locales: []Locale{LocaleEnUS}})
+	if s := translationCtx.GetTranslator(context.Background()).Translate("hello", "en-US"); s != "hello" {
+		t.Errorf("Expected key to be returned, got %q", s)
+	}
+	if s := translationCtx.SingleLocaleTranslator(context.Background()).Translate("hello"); s != "hello" {
+		t.Errorf("Expected key to be returned, got %q", s)
+	}
+}
+
+func TestNewContext_WithContextLogger(t *testing.T) {
+	logger := &mockLogger{}
+	translationCtx := NewContext(context.Background(), mockLocalesProvider{locales: []Locale{LocaleEnUS}}, WithContextLogger(logger))
+	if _, err := translationCtx.SetLocale("xx-XX"); err == nil {
+		t.Fatal("Expected error for unsupported locale")
+	}
+	if !logger.errorCalled || logger.lastFormat != "Failed to set locale: locale=%v&error=%v" {
+		t.Errorf("Expected error to be logged to own logger, got %q", logger.lastFormat)
+	}
+}